@@ -0,0 +1,24 @@
+provider "logdna" {
+  servicekey = "xxxxxxxxxxxxxxxxxxxxxxxx"
+}
+
+variable "archive_access_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "archive_secret_key" {
+  type      = string
+  sensitive = true
+}
+
+resource "logdna_archive" "config" {
+  integration = "s3_compatible"
+  s3_compatible_config {
+    bucket         = "example"
+    endpoint       = "https://minio.example.org:9000"
+    forcepathstyle = true
+    accesskey      = var.archive_access_key
+    secretkey      = var.archive_secret_key
+  }
+}
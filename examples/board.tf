@@ -0,0 +1,39 @@
+provider "logdna" {
+  servicekey = "Your service key goes here"
+}
+
+resource "logdna_category" "my_category" {
+  name = "My Board Category"
+  type = "boards"
+}
+
+resource "logdna_board" "my_board" {
+  name         = "API Health"
+  category_ids = [logdna_category.my_category.id]
+
+  graph {
+    title = "Errors by app"
+    type  = "bar"
+    plot {
+      query    = "level:error"
+      group_by = ["app"]
+    }
+  }
+
+  graph {
+    title      = "Response time"
+    time_range = "24h"
+    plot {
+      query       = "app:api"
+      aggregation = "avg"
+      field       = "response_time"
+      label       = "Average"
+    }
+    plot {
+      query       = "app:api"
+      aggregation = "max"
+      field       = "response_time"
+      label       = "Max"
+    }
+  }
+}
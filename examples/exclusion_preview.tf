@@ -0,0 +1,31 @@
+provider "logdna" {
+  servicekey = "Your service key goes here"
+}
+
+locals {
+  noisy_apps  = ["nginx", "apache"]
+  noisy_query = "response:(>=200 <300)"
+}
+
+data "logdna_exclusion_preview" "http-success" {
+  apps  = local.noisy_apps
+  query = local.noisy_query
+  samples = [
+    jsonencode({ app = "nginx", line = "GET /checkout 502", meta = { response = 502 } }),
+    jsonencode({ app = "nginx", line = "POST /payments 201", meta = { response = 201, path = "/payments" } }),
+  ]
+}
+
+resource "logdna_ingestion_exclusion" "http-success" {
+  title  = "HTTP 2XX"
+  apps   = local.noisy_apps
+  query  = local.noisy_query
+  active = true
+
+  lifecycle {
+    precondition {
+      condition     = length(data.logdna_exclusion_preview.http-success.matched_samples) == 0
+      error_message = "The rule would exclude critical logs: ${join(", ", data.logdna_exclusion_preview.http-success.matched_samples)}"
+    }
+  }
+}
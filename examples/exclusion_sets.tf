@@ -0,0 +1,36 @@
+provider "logdna" {
+  servicekey = "Your service key goes here"
+}
+
+resource "logdna_ingestion_exclusion_set" "all" {
+  rule {
+    title     = "HTTP 2XX"
+    apps      = ["nginx", "apache"]
+    query     = "response:(>=200 <300)"
+    active    = true
+    indexonly = false
+  }
+
+  rule {
+    title  = "Noisy HTTP Paths"
+    apps   = ["nginx", "apache"]
+    query  = "robots.txt OR favicon.ico OR .well-known"
+    active = true
+  }
+}
+
+resource "logdna_stream_exclusion_set" "all" {
+  rule {
+    title  = "HTTP 2XX"
+    apps   = ["nginx", "apache"]
+    query  = "response:(>=200 <300) request:*"
+    active = true
+  }
+
+  rule {
+    title  = "Noisy HTTP Paths"
+    apps   = ["nginx", "apache"]
+    query  = "robots.txt OR favicon.ico OR .well-known"
+    active = true
+  }
+}
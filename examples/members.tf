@@ -0,0 +1,27 @@
+provider "logdna" {
+  servicekey = "Your service key goes here"
+}
+
+resource "logdna_group" "payments_team" {
+  name = "Payments team"
+}
+
+resource "logdna_members" "all" {
+  allowlist = ["breakglass@example.org"]
+
+  member {
+    email = "owner@example.org"
+    role  = "owner"
+  }
+
+  member {
+    email  = "payments@example.org"
+    role   = "member"
+    groups = [logdna_group.payments_team.id]
+  }
+
+  member {
+    email = "auditor@example.org"
+    role  = "readonly"
+  }
+}
@@ -0,0 +1,64 @@
+provider "logdna" {
+  servicekey = "Your service key goes here"
+}
+
+resource "logdna_category" "my_category" {
+  name = "My Screen Category"
+  type = "screens"
+}
+
+resource "logdna_screen" "my_screen" {
+  name         = "API Overview"
+  category_ids = [logdna_category.my_category.id]
+
+  counter_widget {
+    title = "Errors"
+    query = "app:api level:error"
+    layout {
+      x      = 0
+      y      = 0
+      width  = 2
+      height = 2
+    }
+  }
+
+  gauge_widget {
+    title       = "Average response time"
+    query       = "app:api"
+    aggregation = "avg"
+    field       = "response_time"
+    max         = 500
+    layout {
+      x      = 2
+      y      = 0
+      width  = 2
+      height = 2
+    }
+  }
+
+  table_widget {
+    title   = "Latest errors"
+    query   = "app:api level:error"
+    columns = ["host", "message"]
+    limit   = 20
+    layout {
+      x      = 0
+      y      = 2
+      width  = 4
+      height = 4
+    }
+  }
+
+  timeseries_widget {
+    title      = "Requests per host"
+    query      = "app:api"
+    group_by   = ["host"]
+    time_range = "24h"
+    layout {
+      x      = 4
+      y      = 0
+      width  = 4
+      height = 6
+    }
+  }
+}
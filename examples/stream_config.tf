@@ -11,4 +11,12 @@ resource "logdna_stream_config" "config" {
     "broker-2.example.org:9093",
     "broker-3.example.org:9093",
   ]
+
+  sasl_mechanism = "SCRAM-SHA-512"
+  compression    = "zstd"
+
+  tls {
+    ca_pem      = file("${path.module}/kafka-ca.pem")
+    server_name = "kafka.example.org"
+  }
 }
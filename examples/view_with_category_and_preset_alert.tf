@@ -21,15 +21,14 @@ resource "logdna_alert" "my_alert" {
 }
 
 resource "logdna_view" "my_view" {
-  apps       = ["app1", "app2"]
-  categories = ["Demo1", "Demo2"]
-  hosts      = ["host1", "host2"]
-  levels     = ["fatal", "critical"]
-  name       = "Email Alert"
-  query      = "test"
-  tags       = ["host1", "host2"]
-  categories = [logdna_category.my_category.name]
-  presetid   = logdna_alert.my_alert.id
+  apps         = ["app1", "app2"]
+  hosts        = ["host1", "host2"]
+  levels       = ["fatal", "critical"]
+  name         = "Email Alert"
+  query        = "test"
+  tags         = ["host1", "host2"]
+  category_ids = [logdna_category.my_category.id]
+  presetids    = [logdna_alert.my_alert.id]
 
   depends_on = ["logdna_alert.my_alert","logdna_category.my_category"]
 }
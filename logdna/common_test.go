@@ -24,14 +24,16 @@ var rsDefaults = map[string]map[string]string{
 		"name": `"test"`,
 	},
 	"view": {
-		"apps":       "",
-		"categories": "",
-		"presetid":   "",
-		"hosts":      "",
-		"levels":     "",
-		"name":       `"test"`,
-		"query":      `"test"`,
-		"tags":       "",
+		"apps":         "",
+		"categories":   "",
+		"category_ids": "",
+		"presetid":     "",
+		"presetids":    "",
+		"hosts":        "",
+		"levels":       "",
+		"name":         `"test"`,
+		"query":        `"test"`,
+		"tags":         "",
 	},
 	"category": {
 		"name": `"test"`,
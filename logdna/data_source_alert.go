@@ -22,6 +22,11 @@ var boolSchema = &schema.Schema{
 	Type:     schema.TypeBool,
 	Computed: true,
 }
+var sensitiveStrSchema = &schema.Schema{
+	Type:      schema.TypeString,
+	Computed:  true,
+	Sensitive: true,
+}
 var alertProps = map[string]*schema.Schema{
 	"immediate":       strSchema,
 	"operator":        strSchema,
@@ -143,9 +148,9 @@ func getAlertSchema(chnl string) map[string]*schema.Schema {
 			Computed: true,
 		}
 	case "slack":
-		schma["url"] = strSchema
+		schma["url"] = sensitiveStrSchema
 	case "pagerduty":
-		schma["key"] = strSchema
+		schma["key"] = sensitiveStrSchema
 		schma["autoresolve"] = boolSchema
 		schma["autoresolvelimit"] = intSchema
 		schma["autoresolveinterval"] = strSchema
@@ -158,7 +163,8 @@ func getAlertSchema(chnl string) map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
-			Computed: true,
+			Computed:  true,
+			Sensitive: true,
 		}
 	}
 
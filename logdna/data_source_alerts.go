@@ -0,0 +1,85 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlertsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	alerts := []alertResponse{}
+	if err := listRemote(pc, "/v1/config/presetalert", &alerts); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot list the remote presetalert resources",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	nameExp := getRegexp(d, "name_regex")
+
+	ids := []string{}
+	items := []interface{}{}
+	for i := range alerts {
+		alert := &alerts[i]
+		if !matchesRegexp(nameExp, alert.Name) {
+			continue
+		}
+
+		item := map[string]interface{}{
+			"presetid": alert.PresetID,
+			"name":     alert.Name,
+		}
+		integrations, channelDiags := alert.MapChannelsToSchema()
+		diags = append(diags, channelDiags...)
+		for name, value := range integrations {
+			item[fmt.Sprintf("%s_channel", name)] = value
+		}
+
+		ids = append(ids, alert.PresetID)
+		items = append(items, item)
+	}
+
+	appendError(d.Set("ids", ids), &diags)
+	appendError(d.Set("alerts", items), &diags)
+
+	d.SetId(listDataSourceID(ids))
+	return diags
+}
+
+func dataSourceAlerts() *schema.Resource {
+	alertSchema := map[string]*schema.Schema{
+		"presetid": strSchema,
+		"name":     strSchema,
+	}
+	for _, chnl := range []string{"email", "pagerduty", "slack", "webhook"} {
+		alertSchema[fmt.Sprintf("%s_channel", chnl)] = &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: getAlertSchema(chnl),
+			},
+			Computed: true,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceAlertsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": nameRegexSchema,
+			"ids":        strListSchema,
+			"alerts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: alertSchema,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package logdna
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceArchiveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	req := newRequestConfig(
+		pc,
+		"GET",
+		"/v1/config/archiving",
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+
+	log.Printf("[DEBUG] GET archive raw response body %s\n", body)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot read the remote archive resource",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	c := archiveResponse{}
+	err = json.Unmarshal(body, &c)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot unmarshal response from the remote archive resource",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	appendError(d.Set("integration", c.Integration), &diags)
+	appendError(d.Set("status", c.Status), &diags)
+	appendError(d.Set("last_error", c.LastError), &diags)
+
+	// The response mappers only return the fields that are not secrets
+	if integration := getArchiveIntegration(c.Integration); integration != nil {
+		appendError(d.Set(integration.configKey(), []interface{}{integration.response(c)}), &diags)
+	} else {
+		log.Printf("[WARN] Ignoring the configuration of unsupported archive integration %q", c.Integration)
+	}
+
+	d.SetId(archiveConfigID)
+	return diags
+}
+
+func dataSourceArchive() *schema.Resource {
+	dataSchema := map[string]*schema.Schema{
+		"integration": strSchema,
+		"status":      strSchema,
+		"last_error":  strSchema,
+	}
+	for _, integration := range archiveIntegrations {
+		dataSchema[integration.configKey()] = integration.dataSourceSchema()
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceArchiveRead,
+		Schema:      dataSchema,
+	}
+}
@@ -0,0 +1,53 @@
+package logdna
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceArchive(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Exposes the integration, its settings without secrets and its status", func(t *testing.T) {
+		ts, pc := mockListServer(t, map[string]interface{}{
+			"/v1/config/archiving": archiveResponse{
+				Integration: "dos",
+				Space:       "logs",
+				Endpoint:    "nyc3.digitaloceanspaces.com",
+				AccessKey:   "access",
+				SecretKey:   "secret",
+				Status:      "failing",
+				LastError:   "Access Denied",
+			},
+		})
+		defer ts.Close()
+
+		d := schema.TestResourceDataRaw(t, dataSourceArchive().Schema, map[string]interface{}{})
+		diags := dataSourceArchiveRead(context.Background(), d, pc)
+
+		assert.Empty(diags, "No errors")
+		assert.Equal("archive", d.Id())
+		assert.Equal("dos", d.Get("integration"))
+		assert.Equal("failing", d.Get("status"))
+		assert.Equal("Access Denied", d.Get("last_error"))
+		assert.Equal([]interface{}{map[string]interface{}{
+			"space":    "logs",
+			"endpoint": "nyc3.digitaloceanspaces.com",
+		}}, d.Get("dos_config"))
+		assert.Empty(d.Get("s3_config"))
+	})
+
+	t.Run("Returns an error diagnostic when the request fails", func(t *testing.T) {
+		ts, pc := mockListServer(t, map[string]interface{}{})
+		ts.Close()
+
+		d := schema.TestResourceDataRaw(t, dataSourceArchive().Schema, map[string]interface{}{})
+		diags := dataSourceArchiveRead(context.Background(), d, pc)
+
+		assert.True(diags.HasError(), "The message is of type `Error`")
+		assert.Equal("Cannot read the remote archive resource", diags[0].Summary)
+	})
+}
@@ -0,0 +1,78 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCategoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	categoryType := d.Get("type").(string)
+	categories := []categoryResponse{}
+	if err := listRemote(pc, fmt.Sprintf("/v1/config/categories/%s", categoryType), &categories); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot list the remote category resources",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	nameExp := getRegexp(d, "name_regex")
+
+	ids := []string{}
+	items := []interface{}{}
+	for _, category := range categories {
+		if !matchesRegexp(nameExp, category.Name) {
+			continue
+		}
+
+		// Use the same Type:Id format as the ID of the category resource
+		id := fmt.Sprintf("%s:%s", categoryType, category.Id)
+		ids = append(ids, id)
+		items = append(items, map[string]interface{}{
+			"id":   id,
+			"name": category.Name,
+			"type": categoryType,
+		})
+	}
+
+	appendError(d.Set("ids", ids), &diags)
+	appendError(d.Set("categories", items), &diags)
+
+	d.SetId(listDataSourceID(ids))
+	return diags
+}
+
+func dataSourceCategories() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCategoriesRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "views",
+				ValidateFunc: validation.StringInSlice([]string{"views", "boards", "screens"}, false),
+			},
+			"name_regex": nameRegexSchema,
+			"ids":        strListSchema,
+			"categories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":   strSchema,
+						"name": strSchema,
+						"type": strSchema,
+					},
+				},
+			},
+		},
+	}
+}
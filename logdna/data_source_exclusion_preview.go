@@ -0,0 +1,102 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// exclusionRuleMatches tells if an exclusion rule applies to a record: the
+// app and the host must be listed, when the rule lists any, and the line must
+// match the query
+func exclusionRuleMatches(apps []string, hosts []string, query queryNode, r *queryRecord) bool {
+	return matchesAny(apps, r.App) && matchesAny(hosts, r.Host) && query.match(r)
+}
+
+func matchesAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if wildcardMatch(pattern, value, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataSourceExclusionPreviewRead evaluates the rule locally, without any
+// request to the API
+func dataSourceExclusionPreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	query, err := compileQuery(d.Get("query").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	apps := listToStrings(d.Get("apps").([]interface{}))
+	hosts := listToStrings(d.Get("hosts").([]interface{}))
+
+	samples := listToStrings(d.Get("samples").([]interface{}))
+	matches := make([]bool, len(samples))
+	matched := []string{}
+	indexes := []string{}
+	for i, sample := range samples {
+		r, err := parseQueryRecord(sample)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cannot parse the sample log record",
+				Detail:   fmt.Sprintf("samples.%d: %s", i, err),
+			})
+			return diags
+		}
+		matches[i] = exclusionRuleMatches(apps, hosts, query, r)
+		if matches[i] {
+			matched = append(matched, sample)
+			indexes = append(indexes, strconv.Itoa(i))
+		}
+	}
+
+	appendError(d.Set("matches", matches), &diags)
+	appendError(d.Set("matched_samples", matched), &diags)
+
+	d.SetId(listDataSourceID(append(indexes, d.Get("query").(string))))
+	return diags
+}
+
+func dataSourceExclusionPreview() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"samples": {
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			Required: true,
+		},
+		"matches": {
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeBool},
+			Computed: true,
+		},
+		"matched_samples": {
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+	}
+	// The rule is declared like in the exclusion resources
+	for _, key := range exclusionRuleAtLeastOneOfFields {
+		s[key] = exclusionRuleSchema[key]
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceExclusionPreviewRead,
+		Schema:      s,
+	}
+}
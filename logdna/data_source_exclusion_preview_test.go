@@ -0,0 +1,56 @@
+package logdna
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceExclusionPreview_read(t *testing.T) {
+	samples := []interface{}{
+		`{"app": "nginx", "host": "web-1", "line": "GET /healthz 200", "meta": {"response": 200}}`,
+		`{"app": "nginx", "host": "web-1", "line": "GET /checkout 500", "meta": {"response": 500}}`,
+		`{"app": "payments", "host": "web-1", "line": "GET /healthz 200", "meta": {"response": 200}}`,
+		`{"app": "nginx-canary", "host": "canary-1", "level": "info", "line": "GET /robots.txt 200", "meta": {"response": 200}}`,
+	}
+
+	t.Run("Matches apps, hosts and query together", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceExclusionPreview().Schema, map[string]interface{}{
+			"apps":    []interface{}{"nginx*"},
+			"query":   "response:(>=200 <300)",
+			"samples": samples,
+		})
+
+		diags := dataSourceExclusionPreviewRead(context.Background(), d, nil)
+		assert.Empty(t, diags)
+		assert.Equal(t, []interface{}{true, false, false, true}, d.Get("matches"))
+		assert.Equal(t, []interface{}{samples[0], samples[3]}, d.Get("matched_samples"))
+		assert.NotEmpty(t, d.Id())
+	})
+
+	t.Run("Matches every line of the listed hosts without a query", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceExclusionPreview().Schema, map[string]interface{}{
+			"hosts":   []interface{}{"CANARY-1"},
+			"samples": samples,
+		})
+
+		diags := dataSourceExclusionPreviewRead(context.Background(), d, nil)
+		assert.Empty(t, diags)
+		assert.Equal(t, []interface{}{false, false, false, true}, d.Get("matches"))
+	})
+
+	t.Run("Reports invalid samples", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceExclusionPreview().Schema, map[string]interface{}{
+			"query":   "foo",
+			"samples": []interface{}{`{"line": "foo"}`, `["not", "a", "record"]`},
+		})
+
+		diags := dataSourceExclusionPreviewRead(context.Background(), d, nil)
+		if assert.Len(t, diags, 1) {
+			assert.Equal(t, "Cannot parse the sample log record", diags[0].Summary)
+			assert.Regexp(t, "^samples.1: ", diags[0].Detail)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	uri := "/v1/config/keys"
+	if keyType := d.Get("type").(string); keyType != "" {
+		uri = fmt.Sprintf("%s?type=%s", uri, keyType)
+	}
+
+	keys := []keyResponse{}
+	if err := listRemote(pc, uri, &keys); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot list the remote key resources",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	nameExp := getRegexp(d, "name_regex")
+
+	ids := []string{}
+	items := []interface{}{}
+	for _, key := range keys {
+		if !matchesRegexp(nameExp, key.Name) {
+			continue
+		}
+
+		ids = append(ids, key.KeyID)
+		items = append(items, map[string]interface{}{
+			"id":      key.KeyID,
+			"name":    key.Name,
+			"type":    key.Type,
+			"key":     key.Key,
+			"created": key.Created,
+		})
+	}
+
+	appendError(d.Set("ids", ids), &diags)
+	appendError(d.Set("keys", items), &diags)
+
+	d.SetId(listDataSourceID(ids))
+	return diags
+}
+
+func dataSourceKeys() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeysRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ingestion", "service"}, false),
+			},
+			"name_regex": nameRegexSchema,
+			"ids":        strListSchema,
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":      strSchema,
+						"name":    strSchema,
+						"type":    strSchema,
+						"key":     sensitiveStrSchema,
+						"created": intSchema,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package logdna
+
+import (
+	"encoding/json"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Helpers shared by the data sources which list remote resources and filter
+// them client-side, e.g. `logdna_views`
+
+var nameRegexSchema = &schema.Schema{
+	Type:         schema.TypeString,
+	Optional:     true,
+	ValidateFunc: validation.StringIsValidRegExp,
+}
+
+// listRemote fetches a list endpoint and unmarshals the returned array into `out`
+func listRemote(pc *providerConfig, uri string, out interface{}) error {
+	req := newRequestConfig(
+		pc,
+		"GET",
+		uri,
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] GET %s raw response body %s\n", uri, body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// getRegexp compiles an optional regular expression argument. The expression
+// has already been validated by the schema, so it is safe to panic here.
+func getRegexp(d *schema.ResourceData, key string) *regexp.Regexp {
+	exp := d.Get(key).(string)
+	if exp == "" {
+		return nil
+	}
+	return regexp.MustCompile(exp)
+}
+
+func matchesRegexp(exp *regexp.Regexp, value string) bool {
+	return exp == nil || exp.MatchString(value)
+}
+
+// listDataSourceID derives a stable ID for a list data source from its results
+func listDataSourceID(ids []string) string {
+	return strconv.Itoa(schema.HashString(strings.Join(ids, ",")))
+}
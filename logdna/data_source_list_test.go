@@ -0,0 +1,162 @@
+package logdna
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockListServer(t *testing.T, responses map[string]interface{}) (*httptest.Server, *providerConfig) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := responses[r.URL.RequestURI()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Nil(t, json.NewEncoder(w).Encode(res), "No errors")
+	}))
+
+	return ts, &providerConfig{
+		serviceKey: "abc123",
+		baseURL:    ts.URL,
+		httpClient: ts.Client(),
+	}
+}
+
+func TestDataSourceList_views(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/view": []viewResponse{
+			{ViewID: "a", Name: "api errors", Category: []string{"Production"}, Query: "level:error"},
+			{ViewID: "b", Name: "api debug", Category: []string{"Staging"}},
+			{ViewID: "c", Name: "worker errors", Category: []string{"production"}},
+		},
+	})
+	defer ts.Close()
+
+	t.Run("Filters views by name and category", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceViews().Schema, map[string]interface{}{
+			"name_regex": "^api ",
+			"category":   "PRODUCTION",
+		})
+		diags := dataSourceViewsRead(context.Background(), d, pc)
+
+		assert.Empty(diags, "No errors")
+		assert.Equal([]interface{}{"a"}, d.Get("ids"))
+		assert.Equal("api errors", d.Get("views.0.name"))
+		assert.Equal("level:error", d.Get("views.0.query"))
+		assert.NotEmpty(d.Id())
+	})
+
+	t.Run("Returns every view without filters", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceViews().Schema, map[string]interface{}{})
+		diags := dataSourceViewsRead(context.Background(), d, pc)
+
+		assert.Empty(diags, "No errors")
+		assert.Equal([]interface{}{"a", "b", "c"}, d.Get("ids"))
+	})
+}
+
+func TestDataSourceList_alerts(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/presetalert": []alertResponse{
+			{PresetID: "p1", Name: "on-call", Channels: []channelResponse{
+				{Integration: "email", Emails: []string{"test@logdna.com"}, TriggerInterval: "15m"},
+			}},
+			{PresetID: "p2", Name: "digest"},
+		},
+	})
+	defer ts.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceAlerts().Schema, map[string]interface{}{
+		"name_regex": "call",
+	})
+	diags := dataSourceAlertsRead(context.Background(), d, pc)
+
+	assert.Empty(diags, "No errors")
+	assert.Equal([]interface{}{"p1"}, d.Get("ids"))
+	assert.Equal(1, d.Get("alerts.0.email_channel.#"))
+	assert.Equal("test@logdna.com", d.Get("alerts.0.email_channel.0.emails.0"))
+}
+
+func TestDataSourceList_categories(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/categories/boards": []categoryResponse{
+			{Id: "1", Name: "Production", Type: "boards"},
+			{Id: "2", Name: "Staging", Type: "boards"},
+		},
+	})
+	defer ts.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceCategories().Schema, map[string]interface{}{
+		"type":       "boards",
+		"name_regex": "^Stag",
+	})
+	diags := dataSourceCategoriesRead(context.Background(), d, pc)
+
+	assert.Empty(diags, "No errors")
+	assert.Equal([]interface{}{"boards:2"}, d.Get("ids"))
+	assert.Equal("Staging", d.Get("categories.0.name"))
+}
+
+func TestDataSourceList_keys(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/keys?type=ingestion": []keyResponse{
+			{KeyID: "k1", Key: "secret", Name: "agents", Type: "ingestion", Created: 1},
+			{KeyID: "k2", Key: "secret2", Name: "lambda", Type: "ingestion", Created: 2},
+		},
+	})
+	defer ts.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceKeys().Schema, map[string]interface{}{
+		"type":       "ingestion",
+		"name_regex": "agent",
+	})
+	diags := dataSourceKeysRead(context.Background(), d, pc)
+
+	assert.Empty(diags, "No errors")
+	assert.Equal([]interface{}{"k1"}, d.Get("ids"))
+	assert.Equal("secret", d.Get("keys.0.key"))
+}
+
+func TestDataSourceList_members(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/members": []memberResponse{
+			{Email: "owner@logdna.com", Role: "owner"},
+			{Email: "admin@logdna.com", Role: "admin", Groups: []string{"g1"}, InviteStatus: "pending"},
+			{Email: "admin@example.org", Role: "admin"},
+		},
+	})
+	defer ts.Close()
+
+	t.Run("Filters members by role and email", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceMembers().Schema, map[string]interface{}{
+			"role":        "admin",
+			"email_regex": "@logdna\\.com$",
+		})
+		diags := dataSourceMembersRead(context.Background(), d, pc)
+
+		assert.Empty(diags, "No errors")
+		assert.Equal([]interface{}{"admin@logdna.com"}, d.Get("emails"))
+		assert.Equal("g1", d.Get("members.0.groups.0"))
+		assert.Equal("pending", d.Get("members.0.invite_status"))
+	})
+
+	t.Run("Returns an error diagnostic when the request fails", func(t *testing.T) {
+		ts.Close()
+		d := schema.TestResourceDataRaw(t, dataSourceMembers().Schema, map[string]interface{}{})
+		diags := dataSourceMembersRead(context.Background(), d, pc)
+
+		assert.True(diags.HasError(), "The message is of type `Error`")
+		assert.Equal("Cannot list the remote member resources", diags[0].Summary)
+	})
+}
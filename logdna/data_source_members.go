@@ -0,0 +1,79 @@
+package logdna
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	members := []memberResponse{}
+	if err := listRemote(pc, "/v1/config/members", &members); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot list the remote member resources",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	emailExp := getRegexp(d, "email_regex")
+	role := d.Get("role").(string)
+
+	emails := []string{}
+	items := []interface{}{}
+	for _, member := range members {
+		if !matchesRegexp(emailExp, member.Email) {
+			continue
+		}
+		if role != "" && member.Role != role {
+			continue
+		}
+
+		emails = append(emails, member.Email)
+		items = append(items, map[string]interface{}{
+			"email":          member.Email,
+			"role":           member.Role,
+			"groups":         member.Groups,
+			"invite_status":  member.InviteStatus,
+			"account_status": member.AccountStatus,
+		})
+	}
+
+	appendError(d.Set("emails", emails), &diags)
+	appendError(d.Set("members", items), &diags)
+
+	d.SetId(listDataSourceID(emails))
+	return diags
+}
+
+func dataSourceMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMembersRead,
+		Schema: map[string]*schema.Schema{
+			"email_regex": nameRegexSchema,
+			"role": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"emails": strListSchema,
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email":          strSchema,
+						"role":           strSchema,
+						"groups":         strListSchema,
+						"invite_status":  strSchema,
+						"account_status": strSchema,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,211 @@
+package logdna
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var strListSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Elem:     &schema.Schema{Type: schema.TypeString},
+	Computed: true,
+}
+
+func listViews(pc *providerConfig) ([]viewResponse, error) {
+	views := []viewResponse{}
+	if err := listRemote(pc, "/v1/config/view", &views); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// findViewByName returns the only view with the exact given name, optionally
+// scoped to a category (case-insensitive, like the view resource)
+func findViewByName(views []viewResponse, name string, category string) (*viewResponse, error) {
+	matches := []viewResponse{}
+	for _, view := range views {
+		if view.Name != name {
+			continue
+		}
+		if category != "" && !containsFold(view.Category, category) {
+			continue
+		}
+		matches = append(matches, view)
+	}
+
+	scope := ""
+	if category != "" {
+		scope = fmt.Sprintf(" in category %q", category)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no view named %q was found%s", name, scope)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, view := range matches {
+			ids = append(ids, view.ViewID)
+		}
+		return nil, fmt.Errorf(
+			"%d views named %q were found%s (%s), use the viewid instead",
+			len(matches), name, scope, strings.Join(ids, ", "),
+		)
+	}
+}
+
+func containsFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func dataSourceViewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	view := &viewResponse{}
+
+	if id := d.Get("viewid").(string); id != "" {
+		req := newRequestConfig(
+			pc,
+			"GET",
+			fmt.Sprintf("/v1/config/view/%s", id),
+			nil,
+		)
+
+		body, err := req.MakeRequest()
+
+		log.Printf("[DEBUG] GET view raw response body %s\n", body)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cannot read the remote view resource",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+
+		err = json.Unmarshal(body, view)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cannot unmarshal response from the remote view resource",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	} else {
+		views, err := listViews(pc)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cannot list the remote view resources",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+
+		view, err = findViewByName(views, d.Get("name").(string), d.Get("category").(string))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cannot find the remote view resource",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+	log.Printf("[DEBUG] The GET view structure is as follows: %+v\n", view)
+
+	appendError(d.Set("viewid", view.ViewID), &diags)
+	appendError(d.Set("name", view.Name), &diags)
+	appendError(d.Set("query", view.Query), &diags)
+	appendError(d.Set("apps", view.Apps), &diags)
+	appendError(d.Set("categories", view.Category), &diags)
+	appendError(d.Set("hosts", view.Hosts), &diags)
+	appendError(d.Set("levels", view.Levels), &diags)
+	appendError(d.Set("tags", view.Tags), &diags)
+	appendError(d.Set("presetids", view.PresetIds), &diags)
+
+	integrations, channelDiags := view.MapChannelsToSchema()
+	diags = append(diags, channelDiags...)
+	log.Printf("[DEBUG] view MapChannelsToSchema result: %+v\n", integrations)
+
+	for name, value := range integrations {
+		key := fmt.Sprintf("%s_channel", name)
+		appendError(d.Set(key, value), &diags)
+	}
+
+	d.SetId(view.ViewID)
+	return diags
+}
+
+func dataSourceView() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceViewRead,
+		Schema: map[string]*schema.Schema{
+			"viewid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"viewid", "name"},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"category": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"viewid"},
+			},
+			"query":      strSchema,
+			"apps":       strListSchema,
+			"categories": strListSchema,
+			"hosts":      strListSchema,
+			"levels":     strListSchema,
+			"tags":       strListSchema,
+			"presetids":  strListSchema,
+			"email_channel": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: getAlertSchema("email"),
+				},
+				Computed: true,
+			},
+			"pagerduty_channel": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: getAlertSchema("pagerduty"),
+				},
+				Computed: true,
+			},
+			"slack_channel": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: getAlertSchema("slack"),
+				},
+				Computed: true,
+			},
+			"webhook_channel": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: getAlertSchema("webhook"),
+				},
+				Computed: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package logdna
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+const dsViewByID = `
+data "logdna_view" "remote" {
+	viewid = logdna_view.test.id
+}
+`
+
+const dsViewByName = `
+data "logdna_view" "remote" {
+	name     = logdna_view.test.name
+	category = "DEMOCATEGORY1"
+}
+`
+
+func TestDataView_findViewByName(t *testing.T) {
+	assert := assert.New(t)
+	views := []viewResponse{
+		{ViewID: "a", Name: "errors", Category: []string{"DemoCategory1"}},
+		{ViewID: "b", Name: "errors", Category: []string{"DemoCategory2"}},
+		{ViewID: "c", Name: "debug"},
+	}
+
+	t.Run("Finds a view by its exact name", func(t *testing.T) {
+		view, err := findViewByName(views, "debug", "")
+		assert.Nil(err, "No errors")
+		assert.Equal("c", view.ViewID)
+	})
+
+	t.Run("Scopes the lookup to a category", func(t *testing.T) {
+		view, err := findViewByName(views, "errors", "democategory2")
+		assert.Nil(err, "No errors")
+		assert.Equal("b", view.ViewID)
+	})
+
+	t.Run("Errors when no view matches", func(t *testing.T) {
+		_, err := findViewByName(views, "Debug", "")
+		assert.EqualError(err, `no view named "Debug" was found`)
+	})
+
+	t.Run("Errors when the name is ambiguous", func(t *testing.T) {
+		_, err := findViewByName(views, "errors", "")
+		assert.EqualError(err, `2 views named "errors" were found (a, b), use the viewid instead`)
+	})
+}
+
+func TestDataView_Lookup(t *testing.T) {
+	catArgs := map[string]string{"name": `"DemoCategory1"`, "type": `"views"`}
+	catCfg := fmtResourceBlock("category", "test", catArgs, nilOpt, nilLst)
+
+	viewArgs := cloneDefaults(rsDefaults["view"])
+	viewArgs["name"] = `"data-source-view"`
+	viewArgs["apps"] = `["app1", "app2"]`
+	viewArgs["categories"] = `[logdna_category.test.name]`
+	viewArgs["levels"] = `["fatal", "critical"]`
+	chArgs := map[string]map[string]string{
+		"email_channel": cloneDefaults(chnlDefaults["email_channel"]),
+	}
+	viewCfg := fmtTestConfigResource("view", "test", globalPcArgs, viewArgs, chArgs, nilLst)
+	cfg := fmt.Sprintf("%s\n%s", viewCfg, catCfg)
+
+	checks := resource.ComposeTestCheckFunc(
+		testResourceExists("view", "test"),
+		resource.TestCheckResourceAttrPair("data.logdna_view.remote", "viewid", "logdna_view.test", "viewid"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "name", "data-source-view"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "query", "test"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "apps.#", "2"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "categories.#", "1"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "categories.0", "DemoCategory1"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "levels.#", "2"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "email_channel.#", "1"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "email_channel.0.%", "7"),
+		resource.TestCheckResourceAttr("data.logdna_view.remote", "webhook_channel.#", "0"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s\n%s", cfg, dsViewByID),
+				Check:  checks,
+			},
+			{
+				Config: fmt.Sprintf("%s\n%s", cfg, dsViewByName),
+				Check:  checks,
+			},
+			{
+				Config: fmt.Sprintf("%s\n%s", cfg, `
+data "logdna_view" "missing" {
+	name = "this view does not exist"
+}
+`),
+				ExpectError: regexp.MustCompile(`no view named "this view does not exist" was found`),
+			},
+		},
+	})
+}
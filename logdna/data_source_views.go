@@ -0,0 +1,87 @@
+package logdna
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceViewsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	views, err := listViews(pc)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot list the remote view resources",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	nameExp := getRegexp(d, "name_regex")
+	category := d.Get("category").(string)
+
+	ids := []string{}
+	items := []interface{}{}
+	for _, view := range views {
+		if !matchesRegexp(nameExp, view.Name) {
+			continue
+		}
+		if category != "" && !containsFold(view.Category, category) {
+			continue
+		}
+
+		ids = append(ids, view.ViewID)
+		items = append(items, map[string]interface{}{
+			"viewid":     view.ViewID,
+			"name":       view.Name,
+			"query":      view.Query,
+			"apps":       view.Apps,
+			"categories": view.Category,
+			"hosts":      view.Hosts,
+			"levels":     view.Levels,
+			"tags":       view.Tags,
+			"presetids":  view.PresetIds,
+		})
+	}
+
+	appendError(d.Set("ids", ids), &diags)
+	appendError(d.Set("views", items), &diags)
+
+	d.SetId(listDataSourceID(ids))
+	return diags
+}
+
+func dataSourceViews() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceViewsRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": nameRegexSchema,
+			"category": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": strListSchema,
+			"views": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"viewid":     strSchema,
+						"name":       strSchema,
+						"query":      strSchema,
+						"apps":       strListSchema,
+						"categories": strListSchema,
+						"hosts":      strListSchema,
+						"levels":     strListSchema,
+						"tags":       strListSchema,
+						"presetids":  strListSchema,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package logdna
+
+import (
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// emailListSchema is used for the `emails` attribute of every `email_channel`.
+// Addresses are validated at plan time and compared as a case-insensitive set.
+func emailListSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateEmailAddress,
+		},
+		DiffSuppressFunc: suppressEmailListDiff,
+	}
+}
+
+func validateEmailAddress(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	addr, err := mail.ParseAddress(v)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a valid RFC 5322 email address, got: %q (%s)", key, v, err))
+		return
+	}
+	if addr.Address != strings.TrimSpace(v) {
+		errs = append(errs, fmt.Errorf("%q must be a bare email address without a display name, got: %q", key, v))
+	}
+	return
+}
+
+// suppressEmailListDiff is invoked for every element key of the list (and its
+// count), so the whole list is compared at once rather than index by index.
+func suppressEmailListDiff(k, old, new string, d *schema.ResourceData) bool {
+	idx := strings.LastIndex(k, ".")
+	if idx < 0 {
+		return false
+	}
+	o, n := d.GetChange(k[:idx])
+
+	oldEmails := normalizeEmails(o)
+	newEmails := normalizeEmails(n)
+	if len(oldEmails) != len(newEmails) {
+		return false
+	}
+	for i := range oldEmails {
+		if oldEmails[i] != newEmails[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeEmails turns the various shapes of email lists into their canonical
+// form: trimmed, lower-cased, de-duplicated and sorted. The API returns emails
+// as a comma-separated string from a PUT and as an array from a GET.
+func normalizeEmails(emails interface{}) []string {
+	var raw []string
+
+	switch e := emails.(type) {
+	case string:
+		raw = strings.Split(e, ",")
+	case []string:
+		raw = e
+	case []interface{}:
+		for _, v := range e {
+			if s, ok := v.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(raw))
+	normalized := make([]string, 0, len(raw))
+	for _, email := range raw {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		normalized = append(normalized, email)
+	}
+	sort.Strings(normalized)
+
+	return normalized
+}
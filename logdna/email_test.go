@@ -0,0 +1,50 @@
+package logdna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmail_normalizeEmails(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Normalizes an array of emails from a GET", func(t *testing.T) {
+		emails := []interface{}{"B@Example.org", " a@example.org", "b@example.org"}
+		assert.Equal([]string{"a@example.org", "b@example.org"}, normalizeEmails(emails))
+	})
+
+	t.Run("Normalizes a comma-separated string of emails from a PUT", func(t *testing.T) {
+		assert.Equal(
+			[]string{"a@example.org", "c@example.org"},
+			normalizeEmails("C@example.org, a@example.org"),
+		)
+	})
+
+	t.Run("Returns an empty list for unexpected types", func(t *testing.T) {
+		assert.Empty(normalizeEmails(nil))
+		assert.Empty(normalizeEmails(""))
+		assert.Empty(normalizeEmails(42))
+	})
+}
+
+func TestEmail_validateEmailAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Accepts a bare RFC 5322 address", func(t *testing.T) {
+		_, errs := validateEmailAddress("First.Last+tag@example.org", "emails.0")
+		assert.Empty(errs)
+	})
+
+	t.Run("Rejects malformed addresses", func(t *testing.T) {
+		_, errs := validateEmailAddress("not an email", "emails.0")
+		assert.Len(errs, 1)
+		assert.Contains(errs[0].Error(), "must be a valid RFC 5322 email address")
+	})
+
+	t.Run("Rejects addresses with a display name", func(t *testing.T) {
+		_, errs := validateEmailAddress("Jane <jane@example.org>", "emails.0")
+		assert.Len(errs, 1)
+		assert.Contains(errs[0].Error(), "without a display name")
+	})
+}
@@ -1,6 +1,15 @@
 package logdna
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
 
 type exclusionRule struct {
 	ID     string   `json:"id,omitempty"`
@@ -13,6 +22,105 @@ type exclusionRule struct {
 
 var exclusionRuleAtLeastOneOfFields = []string{"apps", "hosts", "query"}
 
+var exclusionNow = time.Now
+
+// exclusionRuleActive tells if a rule should be active at a given time. The
+// bounds of the window are optional RFC3339 timestamps, already validated.
+func exclusionRuleActive(active bool, from string, until string, now time.Time) bool {
+	if !active {
+		return false
+	}
+	if from != "" {
+		start, _ := time.Parse(time.RFC3339, from)
+		if now.Before(start) {
+			return false
+		}
+	}
+	if until != "" {
+		end, _ := time.Parse(time.RFC3339, until)
+		if !now.Before(end) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasExclusionWindow(d interface{ Get(string) interface{} }) bool {
+	return d.Get("active_from").(string) != "" || d.Get("active_until").(string) != ""
+}
+
+// exclusionRuleFromSchema builds the rule to send, with the active flag
+// computed from the activation window at apply time
+func exclusionRuleFromSchema(d *schema.ResourceData) exclusionRule {
+	return exclusionRule{
+		Title: d.Get("title").(string),
+		Active: exclusionRuleActive(
+			d.Get("active").(bool),
+			d.Get("active_from").(string),
+			d.Get("active_until").(string),
+			exclusionNow(),
+		),
+		Apps:  listToStrings(d.Get("apps").([]interface{})),
+		Hosts: listToStrings(d.Get("hosts").([]interface{})),
+		Query: d.Get("query").(string),
+	}
+}
+
+// setExclusionRule stores a remote rule. When an activation window is set,
+// `active` is the configured intent and only `effective_active` follows the
+// remote flag.
+func setExclusionRule(d *schema.ResourceData, ex exclusionRule, diags *diag.Diagnostics) {
+	if !hasExclusionWindow(d) {
+		appendError(d.Set("active", ex.Active), diags)
+	}
+	appendError(d.Set("effective_active", ex.Active), diags)
+	appendError(d.Set("title", ex.Title), diags)
+	appendError(d.Set("apps", ex.Apps), diags)
+	appendError(d.Set("hosts", ex.Hosts), diags)
+	appendError(d.Set("query", ex.Query), diags)
+}
+
+// customizeExclusionDiff plans the flag the rule should have now, so that
+// the plan shows the rule flipping once a bound of its window has passed
+func customizeExclusionDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, key := range []string{"active", "active_from", "active_until"} {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("effective_active")
+		}
+	}
+
+	from := d.Get("active_from").(string)
+	until := d.Get("active_until").(string)
+	if from != "" && until != "" {
+		start, _ := time.Parse(time.RFC3339, from)
+		end, _ := time.Parse(time.RFC3339, until)
+		if !end.After(start) {
+			return fmt.Errorf("active_until (%s) must be after active_from (%s)", until, from)
+		}
+	}
+
+	return d.SetNew("effective_active", exclusionRuleActive(d.Get("active").(bool), from, until, exclusionNow()))
+}
+
+// validateActiveUntil warns about rules whose window is over: they are
+// inactive and can be removed from the configuration
+func validateActiveUntil(val interface{}, path cty.Path) diag.Diagnostics {
+	diags := validation.ToDiagFunc(validation.IsRFC3339Time)(val, path)
+	if diags.HasError() {
+		return diags
+	}
+	until, _ := time.Parse(time.RFC3339, val.(string))
+	if !exclusionNow().Before(until) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "The exclusion rule has expired",
+			Detail:        fmt.Sprintf("The rule stopped being active at %s. It is kept inactive, and can be removed from the configuration.", val),
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
 var exclusionRuleSchema = map[string]*schema.Schema{
 	"id": {
 		Type:     schema.TypeString,
@@ -28,6 +136,20 @@ var exclusionRuleSchema = map[string]*schema.Schema{
 		Default:  false,
 		Optional: true,
 	},
+	"active_from": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsRFC3339Time,
+	},
+	"active_until": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateActiveUntil,
+	},
+	"effective_active": {
+		Type:     schema.TypeBool,
+		Computed: true,
+	},
 	"apps": {
 		Type:         schema.TypeList,
 		Elem:         &schema.Schema{Type: schema.TypeString},
@@ -43,8 +165,10 @@ var exclusionRuleSchema = map[string]*schema.Schema{
 		AtLeastOneOf: exclusionRuleAtLeastOneOfFields,
 	},
 	"query": {
-		Type:         schema.TypeString,
-		Optional:     true,
-		AtLeastOneOf: exclusionRuleAtLeastOneOfFields,
+		Type:             schema.TypeString,
+		Optional:         true,
+		AtLeastOneOf:     exclusionRuleAtLeastOneOfFields,
+		ValidateDiagFunc: validateQuery,
+		DiffSuppressFunc: suppressEquivalentQuery,
 	},
 }
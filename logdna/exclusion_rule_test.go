@@ -0,0 +1,88 @@
+package logdna
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExclusionRule_exclusionRuleActive(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	from := "2022-06-01T10:00:00Z"
+	until := "2022-06-01T14:00:00+02:00"
+
+	assert.True(t, exclusionRuleActive(true, "", "", now))
+	assert.False(t, exclusionRuleActive(false, "", "", now))
+	assert.True(t, exclusionRuleActive(true, from, "", now))
+	assert.False(t, exclusionRuleActive(true, from, "", now.Add(-3*time.Hour)))
+	assert.False(t, exclusionRuleActive(true, "", until, now), "The window ends at 12:00 UTC")
+	assert.True(t, exclusionRuleActive(true, from, until, now.Add(-time.Minute)))
+	assert.False(t, exclusionRuleActive(false, from, until, now.Add(-time.Minute)))
+}
+
+func TestExclusionRule_validateActiveUntil(t *testing.T) {
+	exclusionNow = func() time.Time { return time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { exclusionNow = time.Now }()
+	path := cty.GetAttrPath("active_until")
+
+	assert.Empty(t, validateActiveUntil("2022-06-02T00:00:00Z", path))
+
+	diags := validateActiveUntil("2022-05-31T00:00:00Z", path)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "The exclusion rule has expired", diags[0].Summary)
+
+	diags = validateActiveUntil("tomorrow", path)
+	assert.True(t, diags.HasError())
+}
+
+func TestExclusionRule_customizeExclusionDiff(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	exclusionNow = func() time.Time { return now }
+	defer func() { exclusionNow = time.Now }()
+
+	plan := func(cfg map[string]interface{}, state *terraform.InstanceState) (*terraform.InstanceDiff, error) {
+		return resourceIngestionExclusion().Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), nil)
+	}
+	cfg := map[string]interface{}{
+		"title":        "incident",
+		"apps":         []interface{}{"noisy"},
+		"active":       true,
+		"active_until": "2022-06-01T13:00:00Z",
+	}
+
+	diff, err := plan(cfg, nil)
+	assert.Nil(t, err, "No errors")
+	assert.Equal(t, "true", diff.Attributes["effective_active"].New)
+
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"id":               "abc",
+			"title":            "incident",
+			"apps.#":           "1",
+			"apps.0":           "noisy",
+			"active":           "true",
+			"active_until":     "2022-06-01T13:00:00Z",
+			"effective_active": "true",
+			"indexonly":        "true",
+		},
+	}
+	diff, err = plan(cfg, state)
+	assert.Nil(t, err, "No errors")
+	assert.True(t, diff.Empty(), "Nothing changes within the window")
+
+	now = now.Add(2 * time.Hour)
+	diff, err = plan(cfg, state)
+	assert.Nil(t, err, "No errors")
+	assert.Equal(t, "false", diff.Attributes["effective_active"].New, "The rule flips once the window has passed")
+
+	cfg["active_from"] = "2022-06-01T13:00:00Z"
+	_, err = plan(cfg, state)
+	assert.EqualError(t, err, "active_until (2022-06-01T13:00:00Z) must be after active_from (2022-06-01T13:00:00Z)")
+}
@@ -0,0 +1,165 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Import IDs starting with this prefix are looked up by name rather than used
+// as the remote ID directly, e.g. `name:My View`
+const importNamePrefix = "name:"
+
+type namedObject struct {
+	id   string
+	name string
+}
+
+// findIDByName returns the ID of the only object with the exact given name
+func findIDByName(kind string, name string, objects []namedObject) (string, error) {
+	ids := []string{}
+	for _, obj := range objects {
+		if obj.name == name {
+			ids = append(ids, obj.id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q was found", kind, name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf(
+			"%d %ss named %q were found (%s), import using the ID instead",
+			len(ids), kind, name, strings.Join(ids, ", "),
+		)
+	}
+}
+
+func importViewState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	name := strings.TrimPrefix(d.Id(), importNamePrefix)
+	if name == d.Id() {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	views, err := listViews(m.(*providerConfig))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list views to import %q: %s", d.Id(), err)
+	}
+
+	view, err := findViewByName(views, name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(view.ViewID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// importByName returns an importer which passes IDs through unchanged, and
+// looks `name:<name>` IDs up in the objects returned by `list`
+func importByName(kind string, list func(pc *providerConfig) ([]namedObject, error)) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		name := strings.TrimPrefix(d.Id(), importNamePrefix)
+		if name == d.Id() {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		objects, err := list(m.(*providerConfig))
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %ss to import %q: %s", kind, d.Id(), err)
+		}
+
+		id, err := findIDByName(kind, name, objects)
+		if err != nil {
+			return nil, err
+		}
+
+		d.SetId(id)
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+var importAlertState = importByName("preset alert", func(pc *providerConfig) ([]namedObject, error) {
+	alerts := []alertResponse{}
+	if err := listRemote(pc, "/v1/config/presetalert", &alerts); err != nil {
+		return nil, err
+	}
+
+	objects := make([]namedObject, 0, len(alerts))
+	for _, alert := range alerts {
+		objects = append(objects, namedObject{alert.PresetID, alert.Name})
+	}
+	return objects, nil
+})
+
+var importBoardState = importByName("board", func(pc *providerConfig) ([]namedObject, error) {
+	boards := []boardResponse{}
+	if err := listRemote(pc, "/v1/config/board", &boards); err != nil {
+		return nil, err
+	}
+
+	objects := make([]namedObject, 0, len(boards))
+	for _, board := range boards {
+		objects = append(objects, namedObject{board.BoardID, board.Name})
+	}
+	return objects, nil
+})
+
+var importScreenState = importByName("screen", func(pc *providerConfig) ([]namedObject, error) {
+	screens := []screenResponse{}
+	if err := listRemote(pc, "/v1/config/screen", &screens); err != nil {
+		return nil, err
+	}
+
+	objects := make([]namedObject, 0, len(screens))
+	for _, screen := range screens {
+		objects = append(objects, namedObject{screen.ScreenID, screen.Name})
+	}
+	return objects, nil
+})
+
+// importCategoryState accepts either `<type>:<id>` or `<type>:<name>`. IDs take
+// precedence over names, so existing import IDs keep working unchanged.
+func importCategoryState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	pc := m.(*providerConfig)
+	categoryType, value, err := parseCategoryId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	// Names are only looked up when the value is not the ID of a category
+	req := newRequestConfig(
+		pc,
+		"GET",
+		fmt.Sprintf("/v1/config/categories/%s/%s", categoryType, url.PathEscape(value)),
+		nil,
+	)
+	categoryId := value
+	if _, err := req.MakeRequest(); err != nil {
+		categories, err := listCategories(pc, categoryType)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %s categories to import %q: %s", categoryType, d.Id(), err)
+		}
+
+		objects := make([]namedObject, 0, len(categories))
+		for _, category := range categories {
+			objects = append(objects, namedObject{category.Id, category.Name})
+		}
+		if categoryId, err = findIDByName(categoryType+" category", value, objects); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := d.Set("type", categoryType); err != nil {
+		return nil, err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", categoryType, categoryId))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,121 @@
+package logdna
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func importID(t *testing.T, rs *schema.Resource, id string, pc *providerConfig) (string, error) {
+	d := rs.TestResourceData()
+	d.SetId(id)
+	res, err := rs.Importer.StateContext(context.Background(), d, pc)
+	if err != nil {
+		return "", err
+	}
+	assert.Len(t, res, 1)
+	return res[0].Id(), nil
+}
+
+func TestImporter_view(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/view": []viewResponse{
+			{ViewID: "a", Name: "errors"},
+			{ViewID: "b", Name: "debug"},
+			{ViewID: "c", Name: "debug"},
+		},
+	})
+	defer ts.Close()
+
+	t.Run("Passes IDs through", func(t *testing.T) {
+		id, err := importID(t, resourceView(), "abc123", pc)
+		assert.Nil(err, "No errors")
+		assert.Equal("abc123", id)
+	})
+
+	t.Run("Looks views up by name", func(t *testing.T) {
+		id, err := importID(t, resourceView(), "name:errors", pc)
+		assert.Nil(err, "No errors")
+		assert.Equal("a", id)
+	})
+
+	t.Run("Errors on missing and ambiguous names", func(t *testing.T) {
+		_, err := importID(t, resourceView(), "name:missing", pc)
+		assert.EqualError(err, `no view named "missing" was found`)
+
+		_, err = importID(t, resourceView(), "name:debug", pc)
+		assert.EqualError(err, `2 views named "debug" were found (b, c), use the viewid instead`)
+	})
+}
+
+func TestImporter_alert(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/presetalert": []alertResponse{
+			{PresetID: "p1", Name: "on-call"},
+			{PresetID: "p2", Name: "digest"},
+			{PresetID: "p3", Name: "digest"},
+		},
+	})
+	defer ts.Close()
+
+	t.Run("Looks preset alerts up by name", func(t *testing.T) {
+		id, err := importID(t, resourceAlert(), "name:on-call", pc)
+		assert.Nil(err, "No errors")
+		assert.Equal("p1", id)
+	})
+
+	t.Run("Errors on missing and ambiguous names", func(t *testing.T) {
+		_, err := importID(t, resourceAlert(), "name:missing", pc)
+		assert.EqualError(err, `no preset alert named "missing" was found`)
+
+		_, err = importID(t, resourceAlert(), "name:digest", pc)
+		assert.EqualError(err, `2 preset alerts named "digest" were found (p2, p3), import using the ID instead`)
+	})
+}
+
+func TestImporter_category(t *testing.T) {
+	assert := assert.New(t)
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/categories/views": []categoryResponse{
+			{Id: "1", Name: "Production", Type: "views"},
+			{Id: "2", Name: "Staging", Type: "views"},
+		},
+		"/v1/config/categories/views/2": categoryResponse{Id: "2", Name: "Staging", Type: "views"},
+	})
+	defer ts.Close()
+
+	t.Run("Accepts type and ID", func(t *testing.T) {
+		id, err := importID(t, resourceCategory(), "views:2", pc)
+		assert.Nil(err, "No errors")
+		assert.Equal("views:2", id)
+	})
+
+	t.Run("Accepts type and name", func(t *testing.T) {
+		id, err := importID(t, resourceCategory(), "views:Production", pc)
+		assert.Nil(err, "No errors")
+		assert.Equal("views:1", id)
+	})
+
+	t.Run("Does not list categories to import by ID", func(t *testing.T) {
+		ts, pc := mockListServer(t, map[string]interface{}{
+			"/v1/config/categories/boards/3": categoryResponse{Id: "3", Name: "Production", Type: "boards"},
+		})
+		defer ts.Close()
+
+		id, err := importID(t, resourceCategory(), "boards:3", pc)
+		assert.Nil(err, "No errors")
+		assert.Equal("boards:3", id)
+	})
+
+	t.Run("Errors on unknown names and malformed IDs", func(t *testing.T) {
+		_, err := importID(t, resourceCategory(), "views:Development", pc)
+		assert.EqualError(err, `no views category named "Development" was found`)
+
+		_, err = importID(t, resourceCategory(), "Production", pc)
+		assert.EqualError(err, "Unexpected format of category ID (Production), expected Type:Id")
+	})
+}
@@ -40,19 +40,34 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"logdna_alert": dataSourceAlert(),
+			"logdna_alert":             dataSourceAlert(),
+			"logdna_alerts":            dataSourceAlerts(),
+			"logdna_archive":           dataSourceArchive(),
+			"logdna_categories":        dataSourceCategories(),
+			"logdna_exclusion_preview": dataSourceExclusionPreview(),
+			"logdna_keys":              dataSourceKeys(),
+			"logdna_members":           dataSourceMembers(),
+			"logdna_view":              dataSourceView(),
+			"logdna_views":             dataSourceViews(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"logdna_alert":               resourceAlert(),
-			"logdna_view":                resourceView(),
-			"logdna_category":            resourceCategory(),
-			"logdna_stream_config":       resourceStreamConfig(),
-			"logdna_stream_exclusion":    resourceStreamExclusion(),
-			"logdna_ingestion_exclusion": resourceIngestionExclusion(),
-			"logdna_archive":             resourceArchiveConfig(),
-			"logdna_key":                 resourceKey(),
-			"logdna_index_rate_alert":    resourceIndexRateAlert(),
-			"logdna_member":              resourceMember(),
+			"logdna_alert":                   resourceAlert(),
+			"logdna_view":                    resourceView(),
+			"logdna_board":                   resourceBoard(),
+			"logdna_screen":                  resourceScreen(),
+			"logdna_category":                resourceCategory(),
+			"logdna_stream_config":           resourceStreamConfig(),
+			"logdna_stream_exclusion":        resourceStreamExclusion(),
+			"logdna_ingestion_exclusion":     resourceIngestionExclusion(),
+			"logdna_ingestion_exclusion_set": resourceIngestionExclusionSet(),
+			"logdna_stream_exclusion_set":    resourceStreamExclusionSet(),
+			"logdna_archive":                 resourceArchiveConfig(),
+			"logdna_key":                     resourceKey(),
+			"logdna_index_rate_alert":        resourceIndexRateAlert(),
+			"logdna_member":                  resourceMember(),
+			"logdna_members":                 resourceMembers(),
+			"logdna_group":                   resourceGroup(),
+			"logdna_role":                    resourceRole(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
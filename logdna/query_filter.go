@@ -0,0 +1,171 @@
+package logdna
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Number of `and`/`or`/`not` groups that can be nested below the root filter
+const maxQueryFilterDepth = 3
+
+var queryFilterOperators = map[string]string{
+	"match":  ":",
+	"equals": ":==",
+	"gt":     ":>",
+	"gte":    ":>=",
+	"lt":     ":<",
+	"lte":    ":<=",
+}
+
+var queryFieldExp = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+var queryBareValueExp = regexp.MustCompile(`^[A-Za-z0-9_.@/*+][A-Za-z0-9_.@/*+\-]*$`)
+
+// queryFilterSchema builds the schema of a single query_filter node. Terraform
+// schemas cannot be recursive, so the groups are unrolled to a fixed depth.
+func queryFilterSchema(depth int) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"field": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(queryFieldExp, "must only contain letters, digits, '_', '.' or '-'"),
+		},
+		"operator": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "match",
+			ValidateFunc: validation.StringInSlice([]string{"match", "equals", "gt", "gte", "lt", "lte"}, false),
+		},
+		"value": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+
+	if depth > 0 {
+		for _, group := range []string{"and", "or"} {
+			s[group] = &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: queryFilterSchema(depth - 1),
+				},
+			}
+		}
+		s["not"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: queryFilterSchema(depth - 1),
+			},
+		}
+	}
+
+	return s
+}
+
+// renderQueryFilter compiles the query_filter block into a LogDNA search query
+func renderQueryFilter(filters []interface{}) (string, error) {
+	if len(filters) == 0 {
+		return "", nil
+	}
+	return renderQueryFilterNode(filters[0], false)
+}
+
+func renderQueryFilterNode(n interface{}, nested bool) (string, error) {
+	// Empty blocks have no attributes at all, not even the operator default
+	node, ok := n.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("query_filter blocks cannot be empty")
+	}
+
+	var kinds []string
+	field, _ := node["field"].(string)
+	value, _ := node["value"].(string)
+	if field != "" || value != "" {
+		kinds = append(kinds, "field/value")
+	}
+	for _, group := range []string{"and", "or", "not"} {
+		if children, ok := node[group].([]interface{}); ok && len(children) > 0 {
+			kinds = append(kinds, group)
+		}
+	}
+
+	if len(kinds) != 1 {
+		return "", fmt.Errorf(
+			"each query_filter block must contain exactly one of a field/value condition, `and`, `or` or `not`, got: %v",
+			kinds,
+		)
+	}
+
+	switch kinds[0] {
+	case "and":
+		return renderQueryFilterGroup(node["and"].([]interface{}), " AND ", nested)
+	case "or":
+		return renderQueryFilterGroup(node["or"].([]interface{}), " OR ", nested)
+	case "not":
+		child, err := renderQueryFilterNode(node["not"].([]interface{})[0], true)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + child, nil
+	}
+
+	if value == "" {
+		return "", fmt.Errorf("query_filter field %q requires a value", field)
+	}
+
+	operator, _ := node["operator"].(string)
+	if operator == "" {
+		operator = "match"
+	}
+	if field == "" {
+		if operator != "match" {
+			return "", fmt.Errorf("query_filter operator %q requires a field, free-text terms can only be matched", operator)
+		}
+		return quoteQueryValue(value), nil
+	}
+	separator, ok := queryFilterOperators[operator]
+	if !ok {
+		return "", fmt.Errorf("unsupported query_filter operator: %s", operator)
+	}
+
+	return field + separator + quoteQueryValue(value), nil
+}
+
+func renderQueryFilterGroup(children []interface{}, joiner string, nested bool) (string, error) {
+	parts := make([]string, 0, len(children))
+	for _, child := range children {
+		part, err := renderQueryFilterNode(child, true)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+
+	query := strings.Join(parts, joiner)
+	if nested && len(parts) > 1 {
+		query = "(" + query + ")"
+	}
+	return query, nil
+}
+
+// quoteQueryValue wraps a value in double quotes unless it is a plain word
+// which cannot be mistaken for an operator or a reserved keyword
+func quoteQueryValue(value string) string {
+	switch value {
+	case "AND", "OR", "NOT":
+	default:
+		if queryBareValueExp.MatchString(value) {
+			return value
+		}
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
@@ -0,0 +1,125 @@
+package logdna
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func condition(field, operator, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"field":    field,
+		"operator": operator,
+		"value":    value,
+	}
+}
+
+func TestQueryFilter_renderQueryFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Renders an empty filter as an empty query", func(t *testing.T) {
+		query, err := renderQueryFilter([]interface{}{})
+		assert.Nil(err, "No errors")
+		assert.Equal("", query)
+	})
+
+	t.Run("Renders a single condition for each operator", func(t *testing.T) {
+		cases := map[string]string{
+			"match":  "response:500",
+			"equals": "response:==500",
+			"gt":     "response:>500",
+			"gte":    "response:>=500",
+			"lt":     "response:<500",
+			"lte":    "response:<=500",
+		}
+		for operator, expected := range cases {
+			query, err := renderQueryFilter([]interface{}{condition("response", operator, "500")})
+			assert.Nil(err, "No errors")
+			assert.Equal(expected, query, operator)
+		}
+	})
+
+	t.Run("Renders a free-text term when no field is given", func(t *testing.T) {
+		query, err := renderQueryFilter([]interface{}{condition("", "match", "timeout")})
+		assert.Nil(err, "No errors")
+		assert.Equal("timeout", query)
+	})
+
+	t.Run("Quotes values with whitespace, quotes and reserved words", func(t *testing.T) {
+		assert.Equal(`"connection refused"`, quoteQueryValue("connection refused"))
+		assert.Equal(`"say \"hi\""`, quoteQueryValue(`say "hi"`))
+		assert.Equal(`"a:b"`, quoteQueryValue("a:b"))
+		assert.Equal(`"-debug"`, quoteQueryValue("-debug"))
+		assert.Equal(`"OR"`, quoteQueryValue("OR"))
+		assert.Equal("user@example.org", quoteQueryValue("user@example.org"))
+	})
+
+	t.Run("Renders nested and/or/not groups with parentheses", func(t *testing.T) {
+		filter := map[string]interface{}{
+			"and": []interface{}{
+				condition("app", "match", "api"),
+				map[string]interface{}{
+					"or": []interface{}{
+						condition("level", "match", "error"),
+						condition("response", "gte", "500"),
+					},
+				},
+				map[string]interface{}{
+					"not": []interface{}{
+						condition("host", "match", "canary 1"),
+					},
+				},
+			},
+		}
+
+		query, err := renderQueryFilter([]interface{}{filter})
+		assert.Nil(err, "No errors")
+		assert.Equal(`app:api AND (level:error OR response:>=500) AND NOT host:"canary 1"`, query)
+	})
+
+	t.Run("Errors when a block mixes a condition and a group", func(t *testing.T) {
+		filter := condition("app", "match", "api")
+		filter["or"] = []interface{}{condition("level", "match", "error")}
+
+		_, err := renderQueryFilter([]interface{}{filter})
+		assert.Error(err, "Expected error")
+		assert.Contains(err.Error(), "exactly one of")
+	})
+
+	t.Run("Errors when a field has no value", func(t *testing.T) {
+		_, err := renderQueryFilter([]interface{}{condition("app", "match", "")})
+		assert.Error(err, "Expected error")
+		assert.Contains(err.Error(), `query_filter field "app" requires a value`)
+	})
+
+	t.Run("Errors when a free-text term has an operator", func(t *testing.T) {
+		_, err := renderQueryFilter([]interface{}{condition("", "gt", "500")})
+		assert.EqualError(err, `query_filter operator "gt" requires a field, free-text terms can only be matched`)
+	})
+
+	t.Run("Errors on empty blocks", func(t *testing.T) {
+		_, err := renderQueryFilter([]interface{}{nil})
+		assert.EqualError(err, "query_filter blocks cannot be empty")
+
+		_, err = renderQueryFilter([]interface{}{map[string]interface{}{"and": []interface{}{nil, nil}}})
+		assert.EqualError(err, "query_filter blocks cannot be empty")
+
+		_, err = renderQueryFilter([]interface{}{map[string]interface{}{"not": []interface{}{nil}}})
+		assert.EqualError(err, "query_filter blocks cannot be empty")
+	})
+	t.Run("Rejects malformed filters at plan time", func(t *testing.T) {
+		for _, filter := range []map[string]interface{}{
+			{"not": []interface{}{map[string]interface{}{}}},
+			condition("", "lt", "500"),
+		} {
+			cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":         "test",
+				"query_filter": []interface{}{filter},
+			})
+			_, err := resourceView().Diff(context.Background(), nil, cfg, nil)
+			assert.Error(err, "Expected error")
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package logdna
+
+// Matching of compiled search queries against log records. It follows the
+// LogDNA search semantics closely enough to preview exclusion rules:
+//
+//   - free-text terms, quoted phrases and `line:` terms are case-insensitive
+//     substrings of the line, where `*` matches any characters unless quoted
+//   - `field:value` compares the whole field case-insensitively, `field:*`
+//     only checks that the field exists
+//   - `field:==value` and `field:=value` are exact, case-sensitive matches,
+//     and `field:!=value` is their negation
+//   - `field:>value` and the other comparisons are numeric
+//
+// The `app`, `host`, `level` and `line` fields come from the record, any other
+// field is looked up in its meta, with an optional `meta.` prefix and dots
+// separating nested objects.
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type queryRecord struct {
+	App   string                 `json:"app"`
+	Host  string                 `json:"host"`
+	Level string                 `json:"level"`
+	Line  string                 `json:"line"`
+	Meta  map[string]interface{} `json:"meta"`
+}
+
+type queryNode interface {
+	match(r *queryRecord) bool
+}
+
+type queryAnd []queryNode
+
+type queryOr []queryNode
+
+type queryNot struct {
+	node queryNode
+}
+
+type queryTerm struct {
+	field    string
+	operator string
+	value    string
+	quoted   bool
+}
+
+func (n queryAnd) match(r *queryRecord) bool {
+	for _, node := range n {
+		if !node.match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n queryOr) match(r *queryRecord) bool {
+	for _, node := range n {
+		if node.match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n queryNot) match(r *queryRecord) bool {
+	return !n.node.match(r)
+}
+
+func (n queryTerm) match(r *queryRecord) bool {
+	if n.field == "" || n.operator == "" && strings.EqualFold(n.field, "line") {
+		return n.matchText(r.Line, false)
+	}
+
+	value, ok := r.lookup(n.field)
+	switch n.operator {
+	case "":
+		return ok && n.matchText(value, true)
+	case "==", "=":
+		return ok && value == n.value
+	case "!=":
+		return !ok || value != n.value
+	}
+
+	if !ok {
+		return false
+	}
+	actual, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	expected, err := strconv.ParseFloat(n.value, 64)
+	if err != nil {
+		return false
+	}
+	switch n.operator {
+	case ">":
+		return actual > expected
+	case ">=":
+		return actual >= expected
+	case "<":
+		return actual < expected
+	default:
+		return actual <= expected
+	}
+}
+
+// matchText compares the term with a value, `*` is a wildcard unless quoted
+func (n queryTerm) matchText(value string, anchored bool) bool {
+	if n.quoted {
+		if anchored {
+			return strings.EqualFold(value, n.value)
+		}
+		return strings.Contains(strings.ToLower(value), strings.ToLower(n.value))
+	}
+	return wildcardMatch(n.value, value, anchored)
+}
+
+// lookup returns the value of a field as a string
+func (r *queryRecord) lookup(field string) (string, bool) {
+	switch strings.ToLower(field) {
+	case "app":
+		return r.App, r.App != ""
+	case "host":
+		return r.Host, r.Host != ""
+	case "level":
+		return r.Level, r.Level != ""
+	case "line":
+		return r.Line, true
+	}
+
+	var value interface{} = r.Meta
+	for _, key := range strings.Split(strings.TrimPrefix(field, "meta."), ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if value, ok = object[key]; !ok {
+			return "", false
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		return string(b), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// wildcardMatch matches a case-insensitive pattern where `*` stands for any
+// characters. Anchored patterns must match the whole value, others any part.
+func wildcardMatch(pattern string, value string, anchored bool) bool {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	exp := strings.Join(parts, ".*")
+	if anchored {
+		exp = "^" + exp + "$"
+	}
+	return regexp.MustCompile("(?is)" + exp).MatchString(value)
+}
+
+func unquoteQueryValue(quoted string) string {
+	value, err := strconv.Unquote(quoted)
+	if err != nil {
+		return strings.Trim(quoted, `"`)
+	}
+	return value
+}
+
+// parseQueryRecord decodes a JSON log record, keeping numbers as written
+func parseQueryRecord(sample string) (*queryRecord, error) {
+	r := &queryRecord{}
+	decoder := json.NewDecoder(strings.NewReader(sample))
+	decoder.UseNumber()
+	if err := decoder.Decode(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,80 @@
+package logdna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryMatch_match(t *testing.T) {
+	record, err := parseQueryRecord(`{
+		"app": "api",
+		"host": "web-1",
+		"level": "ERROR",
+		"line": "GET /healthz 200 took 3ms (client: \"kube-probe\")",
+		"meta": {"response": 200, "path": "/healthz", "request": {"method": "GET"}, "canary": false}
+	}`)
+	assert.Nil(t, err, "No errors")
+
+	cases := map[string]bool{
+		"":                               true,
+		"healthz":                        true,
+		"HEALTHZ":                        true,
+		"health*took":                    true,
+		"missing":                        false,
+		`"kube-probe"`:                   true,
+		`"took 3ms"`:                     true,
+		`"took *"`:                       false,
+		"app:api":                        true,
+		"app:API":                        true,
+		"app:ap":                         false,
+		"app:a*":                         true,
+		"app:==API":                      false,
+		"app:==api":                      true,
+		"app:!=api":                      false,
+		"level:error":                    true,
+		"host:web-1 AND app:api":         true,
+		"host:web-2 OR app:api":          true,
+		"host:web-2 app:api":             false,
+		"NOT app:api":                    false,
+		"-app:worker":                    true,
+		"!app:api":                       false,
+		"response:200":                   true,
+		"meta.response:200":              true,
+		"response:>=200":                 true,
+		"response:<200":                  false,
+		"response:(>=200 <300)":          true,
+		"response:(>=300 OR 200)":        true,
+		"response:(>=500 OR <200)":       false,
+		"-response:(>=200 <300)":         false,
+		"path:/healthz":                  true,
+		"request.method:get":             true,
+		"request.method:*":               true,
+		"request.status:*":               false,
+		"canary:false":                   true,
+		"response:>abc":                  false,
+		"line:healthz":                   true,
+		`path:"/healthz"`:                true,
+		"(app:worker OR host:web-*) 3ms": true,
+	}
+	for query, expected := range cases {
+		node, err := compileQuery(query)
+		if assert.Nil(t, err, query) {
+			assert.Equal(t, expected, node.match(record), query)
+		}
+	}
+}
+
+func TestQueryMatch_parseQueryRecord(t *testing.T) {
+	record, err := parseQueryRecord(`{"line": "foo", "meta": {"count": 12345678901234567890}}`)
+	assert.Nil(t, err, "No errors")
+	value, ok := record.lookup("count")
+	assert.True(t, ok)
+	assert.Equal(t, "12345678901234567890", value, "Numbers are kept as written")
+
+	_, ok = record.lookup("app")
+	assert.False(t, ok, "Missing fields are not found")
+
+	_, err = parseQueryRecord(`{"line": `)
+	assert.Error(t, err)
+}
@@ -0,0 +1,388 @@
+package logdna
+
+// A small parser for the LogDNA search syntax. It is used to catch syntax
+// errors at plan time, to normalize whitespace and to preview exclusion rules
+// (see query_match.go), so it is intentionally permissive about what counts as
+// a search term.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type queryTokenKind int
+
+const (
+	queryTokenWord queryTokenKind = iota
+	queryTokenQuoted
+	queryTokenLParen
+	queryTokenRParen
+	queryTokenAnd
+	queryTokenOr
+	queryTokenNot
+	queryTokenEOF
+)
+
+// Comparison operators which may follow `field:`, longest first
+var queryComparisonOperators = []string{"==", "!=", ">=", "<=", "=", ">", "<"}
+
+type queryToken struct {
+	kind     queryTokenKind
+	text     string
+	col      int  // 1-based column of the first character
+	attached bool // true if there is no whitespace before the token
+}
+
+type querySyntaxError struct {
+	msg string
+	col int
+}
+
+func (e *querySyntaxError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.msg, e.col)
+}
+
+func tokenizeQuery(query string) ([]queryToken, error) {
+	runes := []rune(query)
+	tokens := []queryToken{}
+	attached := false
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		col := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			attached = false
+			i++
+			continue
+		case r == '(':
+			tokens = append(tokens, queryToken{queryTokenLParen, "(", col, attached})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{queryTokenRParen, ")", col, attached})
+			i++
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, &querySyntaxError{"unterminated quoted string", col}
+			}
+			tokens = append(tokens, queryToken{queryTokenQuoted, string(runes[i : j+1]), col, attached})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune(`()"`, runes[j]); j++ {
+			}
+			word := string(runes[i:j])
+			kind := queryTokenWord
+			switch word {
+			case "AND", "&&":
+				kind = queryTokenAnd
+			case "OR", "||":
+				kind = queryTokenOr
+			case "NOT", "!", "-":
+				kind = queryTokenNot
+			}
+			tokens = append(tokens, queryToken{kind, word, col, attached})
+			i = j
+		}
+		attached = true
+	}
+
+	tokens = append(tokens, queryToken{queryTokenEOF, "", len(runes) + 1, false})
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	field  string // set within a field group, e.g. response:(>=200 <300)
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	t := p.tokens[p.pos]
+	if t.kind != queryTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := queryOr{node}
+	for p.peek().kind == queryTokenOr {
+		op := p.next()
+		if err := p.expectOperand(op); err != nil {
+			return nil, err
+		}
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	nodes := queryAnd{node}
+	for {
+		switch p.peek().kind {
+		case queryTokenAnd:
+			op := p.next()
+			if err := p.expectOperand(op); err != nil {
+				return nil, err
+			}
+		case queryTokenWord, queryTokenQuoted, queryTokenLParen, queryTokenNot:
+			// Terms next to each other are implicitly joined with AND
+		default:
+			if len(nodes) == 1 {
+				return nodes[0], nil
+			}
+			return nodes, nil
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.peek().kind == queryTokenNot {
+		op := p.next()
+		if err := p.expectOperand(op); err != nil {
+			return nil, err
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	t := p.next()
+
+	switch t.kind {
+	case queryTokenLParen:
+		if p.peek().kind == queryTokenRParen {
+			return nil, &querySyntaxError{"empty parentheses", t.col}
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != queryTokenRParen {
+			return nil, &querySyntaxError{"missing closing parenthesis for '('", t.col}
+		}
+		p.next()
+		return node, nil
+	case queryTokenWord:
+		return p.parseTerm(t)
+	case queryTokenQuoted:
+		return queryTerm{field: p.field, value: unquoteQueryValue(t.text), quoted: true}, nil
+	case queryTokenRParen:
+		return nil, &querySyntaxError{"unbalanced ')'", t.col}
+	case queryTokenEOF:
+		return nil, &querySyntaxError{"unexpected end of query", t.col}
+	default:
+		return nil, &querySyntaxError{fmt.Sprintf("unexpected operator %s", t.text), t.col}
+	}
+}
+
+// parseTerm parses a free-text or `field:[operator]value` term. A leading
+// `-` or `!` negates the term.
+func (p *queryParser) parseTerm(t queryToken) (queryNode, error) {
+	word := strings.TrimLeft(t.text, "-!")
+	negate := func(node queryNode) queryNode {
+		if word != t.text {
+			return queryNot{node}
+		}
+		return node
+	}
+
+	field := p.field
+	value := word
+	if field == "" {
+		idx := strings.Index(word, ":")
+		if idx < 0 {
+			return negate(queryTerm{value: word}), nil
+		}
+		if idx == 0 {
+			return nil, &querySyntaxError{"missing field name before ':'", t.col}
+		}
+		field, value = word[:idx], word[idx+1:]
+	}
+
+	operator := ""
+	for _, op := range queryComparisonOperators {
+		if strings.HasPrefix(value, op) {
+			operator, value = op, value[len(op):]
+			break
+		}
+	}
+	if value != "" {
+		return negate(queryTerm{field: field, operator: operator, value: value}), nil
+	}
+
+	next := p.peek()
+	switch {
+	case next.kind == queryTokenQuoted && next.attached:
+		// A quoted value directly follows the field, e.g. message:"foo bar"
+		p.next()
+		return negate(queryTerm{field: field, operator: operator, value: unquoteQueryValue(next.text), quoted: true}), nil
+	case next.kind == queryTokenLParen && next.attached && operator == "" && p.field == "":
+		// A group of values for the field, e.g. response:(>=200 <300)
+		p.field = field
+		node, err := p.parsePrimary()
+		p.field = ""
+		if err != nil {
+			return nil, err
+		}
+		return negate(node), nil
+	}
+	return nil, &querySyntaxError{
+		fmt.Sprintf("expected a value after %q", t.text),
+		t.col + len([]rune(t.text)),
+	}
+}
+
+func (p *queryParser) expectOperand(op queryToken) error {
+	switch p.peek().kind {
+	case queryTokenEOF, queryTokenRParen, queryTokenAnd, queryTokenOr:
+		return &querySyntaxError{fmt.Sprintf("expected a search term after %s", op.text), p.peek().col}
+	}
+	return nil
+}
+
+// compileQuery parses a search query into a tree which can be matched
+// against log lines. An empty query matches every line.
+func compileQuery(query string) (queryNode, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{tokens: tokens}
+	if p.peek().kind == queryTokenEOF {
+		return queryAnd{}, nil
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != queryTokenEOF {
+		if t.kind == queryTokenRParen {
+			return nil, &querySyntaxError{"unbalanced ')'", t.col}
+		}
+		return nil, &querySyntaxError{fmt.Sprintf("unexpected %q", t.text), t.col}
+	}
+	return node, nil
+}
+
+// parseQuery validates the syntax of a search query and returns its tokens
+func parseQuery(query string) ([]queryToken, error) {
+	if _, err := compileQuery(query); err != nil {
+		return nil, err
+	}
+	return tokenizeQuery(query)
+}
+
+// normalizeQuery collapses whitespace between the tokens of a query while
+// keeping quoted strings untouched
+func normalizeQuery(query string) (string, error) {
+	tokens, err := parseQuery(query)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	var prev *queryToken
+	for i := range tokens {
+		t := &tokens[i]
+		if t.kind == queryTokenEOF {
+			break
+		}
+		if prev != nil && prev.kind != queryTokenLParen && t.kind != queryTokenRParen &&
+			!(t.attached && t.kind == queryTokenQuoted && prev.kind == queryTokenWord) &&
+			!(t.attached && t.kind == queryTokenLParen && strings.HasSuffix(prev.text, ":")) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.text)
+		prev = t
+	}
+	return b.String(), nil
+}
+
+func equivalentQueries(old, new string) bool {
+	if old == new {
+		return true
+	}
+	normalizedOld, err := normalizeQuery(old)
+	if err != nil {
+		return false
+	}
+	normalizedNew, err := normalizeQuery(new)
+	if err != nil {
+		return false
+	}
+	return normalizedOld == normalizedNew
+}
+
+func validateQuery(val interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	query := val.(string)
+	if _, err := parseQuery(query); err != nil {
+		diags = append(diags, invalidQueryDiagnostic(query, err, path))
+	}
+	return diags
+}
+
+// invalidQueryDiagnostic points at the column of syntax errors
+func invalidQueryDiagnostic(query string, err error, path cty.Path) diag.Diagnostic {
+	detail := err.Error()
+	var syntaxErr *querySyntaxError
+	if errors.As(err, &syntaxErr) {
+		detail = fmt.Sprintf("%s\n\n  %s\n  %s^", detail, query, strings.Repeat(" ", syntaxErr.col-1))
+	}
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       "Invalid search query",
+		Detail:        detail,
+		AttributePath: path,
+	}
+}
+
+func suppressEquivalentQuery(k, old, new string, d *schema.ResourceData) bool {
+	return equivalentQueries(old, new)
+}
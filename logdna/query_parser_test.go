@@ -0,0 +1,103 @@
+package logdna
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryParser_parseQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Accepts valid queries", func(t *testing.T) {
+		queries := []string{
+			"",
+			"test",
+			"level:debug my query",
+			"query-foo AND query-bar",
+			`app:api AND (level:error OR response:>=500) AND NOT host:"canary 1"`,
+			`message:"connection refused" -host:canary !app:worker`,
+			"response:==200 || response:<300",
+			"-(app:a OR app:b)",
+			`"quoted \"escaped\" term"`,
+			"url:https://example.org/path",
+			"response:(>=200 <300) request:*",
+			`-level:(debug OR "trace")`,
+		}
+		for _, query := range queries {
+			_, err := parseQuery(query)
+			assert.Nil(err, query)
+		}
+	})
+
+	t.Run("Reports the column of syntax errors", func(t *testing.T) {
+		cases := map[string]string{
+			`app:"api`:              `unterminated quoted string at column 5`,
+			"(app:api OR app:web":   `missing closing parenthesis for '(' at column 1`,
+			"app:api) OR app:web":   `unbalanced ')' at column 8`,
+			"AND app:api":           `unexpected operator AND at column 1`,
+			"app:api AND":           `expected a search term after AND at column 12`,
+			"app:api OR OR app:web": `expected a search term after OR at column 12`,
+			"app:api ()":            `empty parentheses at column 9`,
+			"level: error":          `expected a value after "level:" at column 7`,
+			"response:>= 500":       `expected a value after "response:>=" at column 12`,
+			":value":                `missing field name before ':' at column 1`,
+			"response:>=(200)":      `expected a value after "response:>=" at column 12`,
+		}
+		for query, expected := range cases {
+			_, err := parseQuery(query)
+			if assert.Error(err, query) {
+				assert.Equal(expected, err.Error(), query)
+			}
+		}
+	})
+}
+
+func TestQueryParser_normalizeQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Collapses whitespace outside of quoted strings", func(t *testing.T) {
+		query, err := normalizeQuery("  app:api   AND\n( level:error\tOR  message:\"a   b\" )  ")
+		assert.Nil(err, "No errors")
+		assert.Equal(`app:api AND (level:error OR message:"a   b")`, query)
+	})
+
+	t.Run("Keeps field groups attached to their field", func(t *testing.T) {
+		query, err := normalizeQuery("response:( >=200  <300 ) ( a  b )")
+		assert.Nil(err, "No errors")
+		assert.Equal(`response:(>=200 <300) (a b)`, query)
+	})
+
+	t.Run("Treats whitespace-only differences as equivalent", func(t *testing.T) {
+		assert.True(equivalentQueries("app:api  level:error", "app:api level:error"))
+		assert.False(equivalentQueries("app:api level:error", "app:api level:warn"))
+		assert.False(equivalentQueries(`"a b"`, `"a  b"`))
+	})
+}
+
+func TestQueryParser_validateQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Returns a diagnostic pointing at the error", func(t *testing.T) {
+		path := cty.GetAttrPath("query")
+		diags := validateQuery("app:api AND", path)
+
+		assert.Len(diags, 1, "There was 1 error")
+		assert.True(diags.HasError(), "The message is of type `Error`")
+		assert.Equal("Invalid search query", diags[0].Summary)
+		assert.Equal("expected a search term after AND at column 12\n\n  app:api AND\n             ^", diags[0].Detail)
+		assert.Equal(path, diags[0].AttributePath)
+	})
+
+	t.Run("Returns no diagnostics for a valid query", func(t *testing.T) {
+		assert.Empty(validateQuery("app:api", cty.GetAttrPath("query")))
+	})
+
+	t.Run("Returns a plain diagnostic for other errors", func(t *testing.T) {
+		d := invalidQueryDiagnostic("app:api", errors.New("something else"), cty.GetAttrPath("query"))
+		assert.Equal("Invalid search query", d.Summary)
+		assert.Equal("something else", d.Detail)
+	})
+}
@@ -14,15 +14,66 @@ import (
 )
 
 type viewRequest struct {
-	Apps     []string         `json:"apps,omitempty"`
-	Category []string         `json:"category,omitempty"`
-	Channels []channelRequest `json:"channels,omitempty"`
-	Hosts    []string         `json:"hosts,omitempty"`
-	Levels   []string         `json:"levels,omitempty"`
-	Name     string           `json:"name,omitempty"`
-	Query    string           `json:"query,omitempty"`
-	Tags     []string         `json:"tags,omitempty"`
-	PresetId string           `json:"presetid,omitempty"`
+	Apps      []string         `json:"apps,omitempty"`
+	Category  []string         `json:"category,omitempty"`
+	Channels  []channelRequest `json:"channels,omitempty"`
+	Hosts     []string         `json:"hosts,omitempty"`
+	Levels    []string         `json:"levels,omitempty"`
+	Name      string           `json:"name,omitempty"`
+	Query     string           `json:"query,omitempty"`
+	Tags      []string         `json:"tags,omitempty"`
+	PresetIds *[]string        `json:"presetids,omitempty"`
+}
+
+type boardRequest struct {
+	Name     string              `json:"name,omitempty"`
+	Category []string            `json:"category,omitempty"`
+	Graphs   []boardGraphRequest `json:"graphs"`
+}
+
+type boardGraphRequest struct {
+	GraphID   string             `json:"graphid,omitempty"`
+	Title     string             `json:"title,omitempty"`
+	Type      string             `json:"type,omitempty"`
+	TimeRange string             `json:"timerange,omitempty"`
+	Plots     []boardPlotRequest `json:"plots"`
+}
+
+type boardPlotRequest struct {
+	Query       string   `json:"query"`
+	Aggregation string   `json:"aggregation,omitempty"`
+	Field       string   `json:"field,omitempty"`
+	GroupBy     []string `json:"groupby,omitempty"`
+	Label       string   `json:"label,omitempty"`
+}
+
+type screenRequest struct {
+	Name     string                `json:"name,omitempty"`
+	Category []string              `json:"category,omitempty"`
+	Widgets  []screenWidgetRequest `json:"widgets"`
+}
+
+type screenWidgetRequest struct {
+	WidgetID    string                    `json:"widgetid,omitempty"`
+	Type        string                    `json:"type"`
+	Title       string                    `json:"title,omitempty"`
+	Query       string                    `json:"query"`
+	Aggregation string                    `json:"aggregation,omitempty"`
+	Field       string                    `json:"field,omitempty"`
+	GroupBy     []string                  `json:"groupby,omitempty"`
+	TimeRange   string                    `json:"timerange,omitempty"`
+	Min         *float64                  `json:"min,omitempty"`
+	Max         *float64                  `json:"max,omitempty"`
+	Columns     []string                  `json:"columns,omitempty"`
+	Limit       int                       `json:"limit,omitempty"`
+	Layout      screenWidgetLayoutRequest `json:"layout"`
+}
+
+type screenWidgetLayoutRequest struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"w"`
+	Height int `json:"h"`
 }
 
 type alertRequest struct {
@@ -81,12 +132,33 @@ type indexRateAlertRequest struct {
 	Enabled        bool                         `json:"enabled,omitempty"`
 }
 
+// The built-in member roles, custom roles are managed with logdna_role
+var memberRoles = []string{"owner", "admin", "member", "readonly"}
+
 type memberRequest struct {
 	Email  string   `json:"email,omitempty"`
 	Role   string   `json:"role,omitempty"`
 	Groups []string `json:"groups,omitempty"`
 }
 
+type groupRequest struct {
+	Name    string             `json:"name,omitempty"`
+	Members []string           `json:"members"`
+	Access  groupAccessRequest `json:"access"`
+}
+
+type groupAccessRequest struct {
+	Apps  []string `json:"apps"`
+	Hosts []string `json:"hosts"`
+	Views []string `json:"views"`
+}
+
+type roleRequest struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
 type memberPutRequest struct {
 	Role   string   `json:"role,omitempty"`
 	Groups []string `json:"groups"`
@@ -100,6 +172,18 @@ func (view *viewRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnost
 	view.Name = d.Get("name").(string)
 	view.Query = d.Get("query").(string)
 
+	if filters := d.Get("query_filter").([]interface{}); len(filters) > 0 {
+		query, err := renderQueryFilter(filters)
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cannot render query_filter into a search query",
+				Detail:   err.Error(),
+			})
+		}
+		view.Query = query
+	}
+
 	// Simple arrays
 	view.Apps = listToStrings(d.Get("apps").([]interface{}))
 	view.Category = listToStrings(d.Get("categories").([]interface{}))
@@ -107,7 +191,19 @@ func (view *viewRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnost
 	view.Levels = listToStrings(d.Get("levels").([]interface{}))
 	view.Tags = listToStrings(d.Get("tags").([]interface{}))
 
-	view.PresetId = d.Get("presetid").(string)
+	// NOTE presetid is deprecated but still takes precedence when it is set
+	presetIds := setToStrings(d.Get("presetids").(*schema.Set))
+	if presetID := d.Get("presetid").(string); presetID != "" {
+		presetIds = []string{presetID}
+	}
+	// An explicit empty list is needed to detach the presets of a view, they
+	// are left untouched when the key is missing
+	if len(presetIds) > 0 || d.HasChanges("presetids", "presetid") {
+		if presetIds == nil {
+			presetIds = []string{}
+		}
+		view.PresetIds = &presetIds
+	}
 
 	// Complex array interfaces
 	view.Channels = *aggregateAllChannelsFromSchema(d, &diags)
@@ -115,6 +211,134 @@ func (view *viewRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnost
 	return diags
 }
 
+// blockIDs holds the IDs the API assigned to the blocks of a list, by title
+type blockIDs map[string][]string
+
+// stateBlockIDs collects the IDs of the blocks of a list from the state. The
+// computed IDs of list blocks stay at their position when blocks are inserted,
+// removed or reordered, so they are matched with the declared blocks by title
+// instead.
+func stateBlockIDs(d *schema.ResourceData, key string, idKey string) blockIDs {
+	ids := blockIDs{}
+	old, _ := d.GetChange(key)
+	for _, b := range old.([]interface{}) {
+		block, _ := b.(map[string]interface{})
+		if id, _ := block[idKey].(string); id != "" {
+			title := block["title"].(string)
+			ids[title] = append(ids[title], id)
+		}
+	}
+	return ids
+}
+
+// take returns an unused ID of a block with the given title, if any
+func (ids blockIDs) take(title string) string {
+	if len(ids[title]) == 0 {
+		return ""
+	}
+	id := ids[title][0]
+	ids[title] = ids[title][1:]
+	return id
+}
+
+func (board *boardRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Scalars
+	board.Name = d.Get("name").(string)
+
+	// Simple arrays
+	board.Category = listToStrings(d.Get("categories").([]interface{}))
+
+	// Complex array interfaces
+	board.Graphs = []boardGraphRequest{}
+	graphIDs := stateBlockIDs(d, "graph", "graphid")
+	for _, g := range d.Get("graph").([]interface{}) {
+		graph := g.(map[string]interface{})
+		graphRequest := boardGraphRequest{
+			GraphID:   graphIDs.take(graph["title"].(string)),
+			Title:     graph["title"].(string),
+			Type:      graph["type"].(string),
+			TimeRange: graph["time_range"].(string),
+			Plots:     []boardPlotRequest{},
+		}
+
+		for _, p := range graph["plot"].([]interface{}) {
+			plot := p.(map[string]interface{})
+			graphRequest.Plots = append(graphRequest.Plots, boardPlotRequest{
+				Query:       plot["query"].(string),
+				Aggregation: plot["aggregation"].(string),
+				Field:       plot["field"].(string),
+				GroupBy:     listToStrings(plot["group_by"].([]interface{})),
+				Label:       plot["label"].(string),
+			})
+		}
+
+		board.Graphs = append(board.Graphs, graphRequest)
+	}
+
+	return diags
+}
+
+func (screen *screenRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Scalars
+	screen.Name = d.Get("name").(string)
+
+	// Simple arrays
+	screen.Category = listToStrings(d.Get("categories").([]interface{}))
+
+	// Complex array interfaces, the widgets of each type are kept in the order
+	// they are declared in
+	screen.Widgets = []screenWidgetRequest{}
+	for _, widgetType := range screenWidgetTypes {
+		blockName := fmt.Sprintf("%s_widget", widgetType)
+		widgetIDs := stateBlockIDs(d, blockName, "widgetid")
+		for _, w := range d.Get(blockName).([]interface{}) {
+			widget := w.(map[string]interface{})
+			layout := widget["layout"].([]interface{})[0].(map[string]interface{})
+			widgetRequest := screenWidgetRequest{
+				WidgetID: widgetIDs.take(widget["title"].(string)),
+				Type:     widgetType,
+				Title:    widget["title"].(string),
+				Query:    widget["query"].(string),
+				Layout: screenWidgetLayoutRequest{
+					X:      layout["x"].(int),
+					Y:      layout["y"].(int),
+					Width:  layout["width"].(int),
+					Height: layout["height"].(int),
+				},
+			}
+
+			switch widgetType {
+			case "counter":
+				widgetRequest.Aggregation = widget["aggregation"].(string)
+				widgetRequest.Field = widget["field"].(string)
+			case "gauge":
+				min := widget["min"].(float64)
+				max := widget["max"].(float64)
+				widgetRequest.Aggregation = widget["aggregation"].(string)
+				widgetRequest.Field = widget["field"].(string)
+				widgetRequest.Min = &min
+				widgetRequest.Max = &max
+			case "table":
+				widgetRequest.Columns = listToStrings(widget["columns"].([]interface{}))
+				widgetRequest.Limit = widget["limit"].(int)
+			case "timeseries":
+				widgetRequest.Aggregation = widget["aggregation"].(string)
+				widgetRequest.Field = widget["field"].(string)
+				widgetRequest.GroupBy = listToStrings(widget["group_by"].([]interface{}))
+				widgetRequest.TimeRange = widget["time_range"].(string)
+			}
+
+			screen.Widgets = append(screen.Widgets, widgetRequest)
+		}
+	}
+
+	return diags
+}
+
 func (alert *alertRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -180,7 +404,7 @@ func (member *memberRequest) CreateRequestBody(d *schema.ResourceData) diag.Diag
 	// Scalars
 	member.Email = d.Get("email").(string)
 	member.Role = d.Get("role").(string)
-	member.Groups = listToStrings(d.Get("groups").([]interface{}))
+	member.Groups = setToStrings(d.Get("groups").(*schema.Set))
 
 	return diags
 }
@@ -190,7 +414,41 @@ func (member *memberPutRequest) CreateRequestBody(d *schema.ResourceData) diag.D
 
 	// Scalars
 	member.Role = d.Get("role").(string)
-	member.Groups = listToStrings(d.Get("groups").([]interface{}))
+	member.Groups = setToStrings(d.Get("groups").(*schema.Set))
+
+	return diags
+}
+
+func (group *groupRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Scalars
+	group.Name = d.Get("name").(string)
+
+	// Simple arrays
+	group.Members = normalizeEmails(setToStrings(d.Get("members").(*schema.Set)))
+
+	// Complex array interfaces
+	group.Access = groupAccessRequest{Apps: []string{}, Hosts: []string{}, Views: []string{}}
+	if access := d.Get("access").([]interface{}); len(access) > 0 && access[0] != nil {
+		a := access[0].(map[string]interface{})
+		group.Access.Apps = listToStrings(a["apps"].([]interface{}))
+		group.Access.Hosts = listToStrings(a["hosts"].([]interface{}))
+		group.Access.Views = setToStrings(a["views"].(*schema.Set))
+	}
+
+	return diags
+}
+
+func (role *roleRequest) CreateRequestBody(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Scalars
+	role.Name = d.Get("name").(string)
+	role.Description = d.Get("description").(string)
+
+	// Simple arrays
+	role.Permissions = setToStrings(d.Get("permissions").(*schema.Set))
 
 	return diags
 }
@@ -305,11 +563,7 @@ func iterateIndexRateAlertWebhookType(
 
 	for _, entry := range listEntries {
 		e := entry.(map[string]interface{})
-		headersMap := make(map[string]string)
-
-		for k, v := range e["headers"].(map[string]interface{}) {
-			headersMap[k] = v.(string)
-		}
+		headersMap := mergeHeaders(e)
 
 		var c interface{}
 		var bt map[string]interface{}
@@ -341,13 +595,8 @@ func iterateIndexRateAlertWebhookType(
 }
 
 func emailChannelRequest(s map[string]interface{}) channelRequest {
-	var emails []string
-	for _, email := range s["emails"].([]interface{}) {
-		emails = append(emails, email.(string))
-	}
-
 	c := channelRequest{
-		Emails:          emails,
+		Emails:          normalizeEmails(s["emails"]),
 		Immediate:       s["immediate"].(string),
 		Integration:     EMAIL,
 		Operator:        s["operator"].(string),
@@ -395,11 +644,7 @@ func webHookChannelRequest(
 	s map[string]interface{},
 	diags *diag.Diagnostics,
 ) channelRequest {
-	headersMap := make(map[string]string)
-
-	for k, v := range s["headers"].(map[string]interface{}) {
-		headersMap[k] = v.(string)
-	}
+	headersMap := mergeHeaders(s)
 
 	c := channelRequest{
 		Headers:         headersMap,
@@ -432,6 +677,25 @@ func webHookChannelRequest(
 	return c
 }
 
+// mergeHeaders combines the plain and sensitive webhook headers into the single
+// map expected by the API. Sensitive headers win if a name is declared twice.
+func mergeHeaders(s map[string]interface{}) map[string]string {
+	headersMap := make(map[string]string)
+
+	for _, key := range []string{"headers", "sensitive_headers"} {
+		headers, _ := s[key].(map[string]interface{})
+		for k, v := range headers {
+			headersMap[k] = v.(string)
+		}
+	}
+
+	return headersMap
+}
+
+func setToStrings(set *schema.Set) []string {
+	return listToStrings(set.List())
+}
+
 func listToStrings(list []interface{}) []string {
 	strs := make([]string, 0, len(list))
 	for _, elem := range list {
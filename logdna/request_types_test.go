@@ -1,9 +1,13 @@
 package logdna
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,3 +33,331 @@ func TestRequestTypes_iterateIntegrationType(t *testing.T) {
 		assert.Equal("Unrecognized integration: NOPE", err.Detail, "Detail")
 	})
 }
+
+func TestRequestTypes_viewPresetIds(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Sends every preset ID from presetids", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceView().Schema, map[string]interface{}{
+			"name":      "test",
+			"presetids": []interface{}{"preset1", "preset2"},
+		})
+
+		view := viewRequest{}
+		diags := view.CreateRequestBody(d)
+
+		assert.False(diags.HasError(), "No errors")
+		assert.ElementsMatch([]string{"preset1", "preset2"}, *view.PresetIds)
+	})
+
+	t.Run("Sends the deprecated presetid as a single preset ID", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceView().Schema, map[string]interface{}{
+			"name":     "test",
+			"presetid": "preset1",
+		})
+
+		view := viewRequest{}
+		diags := view.CreateRequestBody(d)
+
+		assert.False(diags.HasError(), "No errors")
+		assert.Equal([]string{"preset1"}, *view.PresetIds)
+	})
+
+	t.Run("Sends an empty list when the presets are removed", func(t *testing.T) {
+		state := &terraform.InstanceState{
+			ID: "view1",
+			Attributes: map[string]string{
+				"id":          "view1",
+				"name":        "test",
+				"presetids.#": "1",
+				"presetids.0": "preset1",
+			},
+		}
+		cfg := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "test"})
+		diff, err := resourceView().Diff(context.Background(), state, cfg, nil)
+		assert.Nil(err, "No errors")
+		d, err := schema.InternalMap(resourceView().Schema).Data(state, diff)
+		assert.Nil(err, "No errors")
+
+		view := viewRequest{}
+		diags := view.CreateRequestBody(d)
+
+		assert.False(diags.HasError(), "No errors")
+		body, _ := json.Marshal(view)
+		assert.Contains(string(body), `"presetids":[]`)
+	})
+
+	t.Run("Omits presetids when there are none", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceView().Schema, map[string]interface{}{
+			"name": "test",
+		})
+
+		view := viewRequest{}
+		diags := view.CreateRequestBody(d)
+
+		assert.False(diags.HasError(), "No errors")
+		assert.Nil(view.PresetIds)
+		body, _ := json.Marshal(view)
+		assert.NotContains(string(body), "presetids")
+	})
+}
+
+func TestRequestTypes_webHookChannelRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Merges sensitive_headers into the request headers", func(t *testing.T) {
+		var diags diag.Diagnostics
+		channel := map[string]interface{}{
+			"bodytemplate":    "",
+			"immediate":       "false",
+			"method":          "post",
+			"operator":        "presence",
+			"terminal":        "true",
+			"triggerinterval": "15m",
+			"triggerlimit":    15,
+			"url":             "https://yourwebhook/endpoint",
+			"headers": map[string]interface{}{
+				"Content-Type":  "application/json",
+				"Authorization": "overridden",
+			},
+			"sensitive_headers": map[string]interface{}{
+				"Authorization": "Bearer secret",
+			},
+		}
+
+		c := webHookChannelRequest(channel, &diags)
+
+		assert.False(diags.HasError(), "No errors")
+		assert.Equal(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": "Bearer secret",
+		}, c.Headers)
+	})
+}
+
+func TestRequestTypes_boardRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	d := schema.TestResourceDataRaw(t, resourceBoard().Schema, map[string]interface{}{
+		"name":       "test",
+		"categories": []interface{}{"DemoCategory1"},
+		"graph": []interface{}{
+			map[string]interface{}{
+				"title": "Latency",
+				"plot": []interface{}{
+					map[string]interface{}{
+						"query":       "app:api",
+						"aggregation": "avg",
+						"field":       "response_time",
+						"group_by":    []interface{}{"host"},
+					},
+				},
+			},
+		},
+	})
+
+	board := boardRequest{}
+	diags := board.CreateRequestBody(d)
+
+	assert.False(diags.HasError(), "No errors")
+	assert.Equal(boardRequest{
+		Name:     "test",
+		Category: []string{"DemoCategory1"},
+		Graphs: []boardGraphRequest{
+			{
+				Title:     "Latency",
+				Type:      "line",
+				TimeRange: "1h",
+				Plots: []boardPlotRequest{
+					{
+						Query:       "app:api",
+						Aggregation: "avg",
+						Field:       "response_time",
+						GroupBy:     []string{"host"},
+					},
+				},
+			},
+		},
+	}, board)
+}
+
+func TestRequestTypes_boardGraphIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	state := &terraform.InstanceState{
+		ID: "board1",
+		Attributes: map[string]string{
+			"id":                   "board1",
+			"name":                 "test",
+			"graph.#":              "2",
+			"graph.0.graphid":      "graph1",
+			"graph.0.title":        "Errors",
+			"graph.0.plot.#":       "1",
+			"graph.0.plot.0.query": "level:error",
+			"graph.1.graphid":      "graph2",
+			"graph.1.title":        "Latency",
+			"graph.1.plot.#":       "1",
+			"graph.1.plot.0.query": "app:api",
+		},
+	}
+	plot := func(query string) []interface{} {
+		return []interface{}{map[string]interface{}{"query": query}}
+	}
+	// A graph is inserted first and the others are swapped
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "test",
+		"graph": []interface{}{
+			map[string]interface{}{"title": "Traffic", "plot": plot("app:nginx")},
+			map[string]interface{}{"title": "Latency", "plot": plot("app:api")},
+			map[string]interface{}{"title": "Errors", "plot": plot("level:error")},
+		},
+	})
+	diff, err := resourceBoard().Diff(context.Background(), state, cfg, nil)
+	assert.Nil(err, "No errors")
+	d, err := schema.InternalMap(resourceBoard().Schema).Data(state, diff)
+	assert.Nil(err, "No errors")
+
+	board := boardRequest{}
+	diags := board.CreateRequestBody(d)
+
+	assert.False(diags.HasError(), "No errors")
+	ids := []string{}
+	for _, graph := range board.Graphs {
+		ids = append(ids, graph.GraphID)
+	}
+	assert.Equal([]string{"", "graph2", "graph1"}, ids, "Graph IDs follow their titles")
+}
+
+func TestRequestTypes_screenRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	layout := []interface{}{
+		map[string]interface{}{"x": 0, "y": 0, "width": 2, "height": 2},
+	}
+	d := schema.TestResourceDataRaw(t, resourceScreen().Schema, map[string]interface{}{
+		"name": "test",
+		"gauge_widget": []interface{}{
+			map[string]interface{}{
+				"query":  "app:api",
+				"field":  "response_time",
+				"max":    500,
+				"layout": layout,
+			},
+		},
+		"counter_widget": []interface{}{
+			map[string]interface{}{
+				"title":  "Errors",
+				"query":  "level:error",
+				"layout": layout,
+			},
+		},
+	})
+
+	screen := screenRequest{}
+	diags := screen.CreateRequestBody(d)
+
+	min := 0.0
+	max := 500.0
+	assert.False(diags.HasError(), "No errors")
+	assert.Equal([]screenWidgetRequest{
+		{
+			Type:        "counter",
+			Title:       "Errors",
+			Query:       "level:error",
+			Aggregation: "count",
+			Layout:      screenWidgetLayoutRequest{Width: 2, Height: 2},
+		},
+		{
+			Type:        "gauge",
+			Query:       "app:api",
+			Aggregation: "count",
+			Field:       "response_time",
+			Min:         &min,
+			Max:         &max,
+			Layout:      screenWidgetLayoutRequest{Width: 2, Height: 2},
+		},
+	}, screen.Widgets)
+}
+
+func TestRequestTypes_screenWidgetIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	layout := func(x int) []interface{} {
+		return []interface{}{map[string]interface{}{"x": x, "y": 0, "width": 1, "height": 1}}
+	}
+	state := &terraform.InstanceState{
+		ID: "screen1",
+		Attributes: map[string]string{
+			"id":                               "screen1",
+			"name":                             "test",
+			"counter_widget.#":                 "2",
+			"counter_widget.0.widgetid":        "w1",
+			"counter_widget.0.title":           "Errors",
+			"counter_widget.0.layout.#":        "1",
+			"counter_widget.0.layout.0.x":      "0",
+			"counter_widget.0.layout.0.width":  "1",
+			"counter_widget.0.layout.0.height": "1",
+			"counter_widget.1.widgetid":        "w2",
+			"counter_widget.1.title":           "Requests",
+			"counter_widget.1.layout.#":        "1",
+			"counter_widget.1.layout.0.x":      "1",
+			"counter_widget.1.layout.0.width":  "1",
+			"counter_widget.1.layout.0.height": "1",
+		},
+	}
+	// The first widget is removed
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "test",
+		"counter_widget": []interface{}{
+			map[string]interface{}{"title": "Requests", "layout": layout(1)},
+		},
+	})
+	diff, err := resourceScreen().Diff(context.Background(), state, cfg, nil)
+	assert.Nil(err, "No errors")
+	d, err := schema.InternalMap(resourceScreen().Schema).Data(state, diff)
+	assert.Nil(err, "No errors")
+
+	screen := screenRequest{}
+	diags := screen.CreateRequestBody(d)
+
+	assert.False(diags.HasError(), "No errors")
+	if assert.Len(screen.Widgets, 1) {
+		assert.Equal("w2", screen.Widgets[0].WidgetID, "Widget IDs follow their titles")
+	}
+}
+
+func TestRequestTypes_groupRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Sends an unrestricted access when no access block is set", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceGroup().Schema, map[string]interface{}{
+			"name":    "test",
+			"members": []interface{}{"B@example.org", "a@example.org"},
+		})
+
+		group := groupRequest{}
+		diags := group.CreateRequestBody(d)
+
+		assert.False(diags.HasError(), "No errors")
+		assert.Equal([]string{"a@example.org", "b@example.org"}, group.Members)
+		assert.Equal(groupAccessRequest{Apps: []string{}, Hosts: []string{}, Views: []string{}}, group.Access)
+	})
+
+	t.Run("Sends the access scopes", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceGroup().Schema, map[string]interface{}{
+			"name": "test",
+			"access": []interface{}{
+				map[string]interface{}{
+					"apps":  []interface{}{"app1"},
+					"views": []interface{}{"view1"},
+				},
+			},
+		})
+
+		group := groupRequest{}
+		diags := group.CreateRequestBody(d)
+
+		assert.False(diags.HasError(), "No errors")
+		assert.Equal(groupAccessRequest{Apps: []string{"app1"}, Hosts: []string{}, Views: []string{"view1"}}, group.Access)
+	})
+}
@@ -90,6 +90,7 @@ func resourceAlertRead(ctx context.Context, d *schema.ResourceData, m interface{
 	// Convert types to maps for setting the schema
 	integrations, diags := alert.MapChannelsToSchema()
 	log.Printf("[DEBUG] presetalert MapChannelsToSchema result: %+v\n", integrations)
+	splitSensitiveHeaders(d, integrations[WEBHOOK])
 
 	// Store the responses in the schema - note that this should also NUKE missing
 	// integrations since we have done a PUT operation. Thus, remove non-existing things.
@@ -158,7 +159,7 @@ func resourceAlert() *schema.Resource {
 		UpdateContext: resourceAlertUpdate,
 		DeleteContext: resourceAlertDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importAlertState,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -171,13 +172,7 @@ func resourceAlert() *schema.Resource {
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"emails": {
-							Type:     schema.TypeList,
-							Required: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
+						"emails": emailListSchema(),
 						"immediate": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -224,8 +219,9 @@ func resourceAlert() *schema.Resource {
 							Default:  "false",
 						},
 						"key": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
 						},
 						"operator": {
 							Type:     schema.TypeString,
@@ -310,8 +306,9 @@ func resourceAlert() *schema.Resource {
 							},
 						},
 						"url": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
 						},
 					},
 				},
@@ -347,7 +344,16 @@ func resourceAlert() *schema.Resource {
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
-							Optional: true,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"sensitive_headers": {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:  true,
+							Sensitive: true,
 						},
 						"immediate": {
 							Type:     schema.TypeString,
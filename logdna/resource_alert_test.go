@@ -358,8 +358,8 @@ func TestAlert_BulkChannels(t *testing.T) {
 					testResourceExists("alert", "new"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "name", "test"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.#", "2"),
-					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.0.%", "9"),
-					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.1.%", "9"),
+					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.0.%", "10"),
+					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.1.%", "10"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "email_channel.#", "0"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "pagerduty_channel.#", "0"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "slack_channel.#", "0"),
@@ -412,7 +412,7 @@ func TestAlert_MultipleChannels(t *testing.T) {
 					resource.TestCheckResourceAttr("logdna_alert.new", "slack_channel.0.triggerlimit", "15"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "slack_channel.0.url", "https://hooks.slack.com/services/identifier/secret"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.#", "1"),
-					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.0.%", "9"),
+					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.0.%", "10"),
 					// The JSON will have newlines per our API which uses JSON.stringify(obj, null, 2) as the value
 					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.0.bodytemplate", "{\n  \"fields\": {\n    \"description\": \"{{ matches }} matches found for {{ name }}\",\n    \"issuetype\": {\n      \"name\": \"Bug\"\n    },\n    \"project\": {\n      \"key\": \"test\"\n    },\n    \"summary\": \"Alert from {{ name }}\"\n  }\n}"),
 					resource.TestCheckResourceAttr("logdna_alert.new", "webhook_channel.0.headers.%", "2"),
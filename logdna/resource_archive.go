@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const archiveConfigID = "archive"
 
 type ibmConfig struct {
+	Integration        string `json:"integration"`
 	Bucket             string `json:"bucket"`
 	Endpoint           string `json:"endpoint"`
 	APIKey             string `json:"apikey"`
@@ -19,149 +22,392 @@ type ibmConfig struct {
 }
 
 type s3Config struct {
-	Bucket string `json:"bucket"`
+	Integration string `json:"integration"`
+	Bucket      string `json:"bucket"`
+}
+
+type s3CompatibleConfig struct {
+	Integration    string `json:"integration"`
+	Bucket         string `json:"bucket"`
+	Endpoint       string `json:"endpoint"`
+	Region         string `json:"region,omitempty"`
+	ForcePathStyle bool   `json:"forcepathstyle"`
+	AccessKey      string `json:"accesskey"`
+	SecretKey      string `json:"secretkey"`
 }
 
 type azblobConfig struct {
+	Integration string `json:"integration"`
 	AccountName string `json:"accountname"`
 	AccountKey  string `json:"accountkey"`
 }
 
 type gcsConfig struct {
-	Bucket    string `json:"bucket"`
-	ProjectID string `json:"projectid"`
+	Integration string `json:"integration"`
+	Bucket      string `json:"bucket"`
+	ProjectID   string `json:"projectid"`
 }
 
 type dosConfig struct {
-	Space     string `json:"space"`
-	Endpoint  string `json:"endpoint"`
-	AccessKey string `json:"accesskey"`
-	SecretKey string `json:"secretkey"`
+	Integration string `json:"integration"`
+	Space       string `json:"space"`
+	Endpoint    string `json:"endpoint"`
+	AccessKey   string `json:"accesskey"`
+	SecretKey   string `json:"secretkey"`
 }
 
 type swiftConfig struct {
-	AuthURL    string `json:"authurl"`
-	Expires    int    `json:"expires,omitempty"`
-	Username   string `json:"username"`
-	Password   string `json:"password"`
-	TenantName string `json:"tenantname"`
+	Integration string `json:"integration"`
+	AuthURL     string `json:"authurl"`
+	Expires     int    `json:"expires,omitempty"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	TenantName  string `json:"tenantname"`
+}
+
+// archiveIntegration describes how an archiving integration is configured:
+// the fields of its `<name>_config` block, how the block is turned into a
+// request, and how the remote configuration maps back to the block.
+// Secrets are write-only (see secret.go): they are sent from the raw
+// configuration and only their hash is kept in the state.
+type archiveIntegration struct {
+	name       string
+	fields     map[string]schema.ValueType
+	required   []string
+	secrets    []string
+	validators map[string]schema.SchemaValidateFunc
+	request    func(config map[string]interface{}, secret func(string) string) interface{}
+	response   func(cn archiveResponse) map[string]interface{}
+}
+
+// archiveIntegrations is ordered as the integrations are documented
+var archiveIntegrations = []*archiveIntegration{
+	{
+		name: "ibm",
+		fields: map[string]schema.ValueType{
+			"bucket":             schema.TypeString,
+			"endpoint":           schema.TypeString,
+			"apikey":             schema.TypeString,
+			"resourceinstanceid": schema.TypeString,
+		},
+		required: []string{"bucket", "endpoint", "apikey", "resourceinstanceid"},
+		secrets:  []string{"apikey"},
+		request: func(config map[string]interface{}, secret func(string) string) interface{} {
+			return ibmConfig{
+				Integration:        "ibm",
+				Bucket:             config["bucket"].(string),
+				Endpoint:           config["endpoint"].(string),
+				APIKey:             secret("apikey"),
+				ResourceInstanceID: config["resourceinstanceid"].(string),
+			}
+		},
+		response: func(cn archiveResponse) map[string]interface{} {
+			return map[string]interface{}{
+				"bucket":             cn.Bucket,
+				"endpoint":           cn.Endpoint,
+				"resourceinstanceid": cn.ResourceInstanceID,
+			}
+		},
+	},
+	{
+		name: "s3",
+		fields: map[string]schema.ValueType{
+			"bucket": schema.TypeString,
+		},
+		required: []string{"bucket"},
+		request: func(config map[string]interface{}, secret func(string) string) interface{} {
+			return s3Config{
+				Integration: "s3",
+				Bucket:      config["bucket"].(string),
+			}
+		},
+		response: func(cn archiveResponse) map[string]interface{} {
+			return map[string]interface{}{
+				"bucket": cn.Bucket,
+			}
+		},
+	},
+	{
+		name: "azblob",
+		fields: map[string]schema.ValueType{
+			"accountname": schema.TypeString,
+			"accountkey":  schema.TypeString,
+		},
+		required: []string{"accountname", "accountkey"},
+		secrets:  []string{"accountkey"},
+		request: func(config map[string]interface{}, secret func(string) string) interface{} {
+			return azblobConfig{
+				Integration: "azblob",
+				AccountName: config["accountname"].(string),
+				AccountKey:  secret("accountkey"),
+			}
+		},
+		response: func(cn archiveResponse) map[string]interface{} {
+			return map[string]interface{}{
+				"accountname": cn.AccountName,
+			}
+		},
+	},
+	{
+		name: "gcs",
+		fields: map[string]schema.ValueType{
+			"bucket":    schema.TypeString,
+			"projectid": schema.TypeString,
+		},
+		required: []string{"bucket", "projectid"},
+		request: func(config map[string]interface{}, secret func(string) string) interface{} {
+			return gcsConfig{
+				Integration: "gcs",
+				Bucket:      config["bucket"].(string),
+				ProjectID:   config["projectid"].(string),
+			}
+		},
+		response: func(cn archiveResponse) map[string]interface{} {
+			return map[string]interface{}{
+				"bucket":    cn.Bucket,
+				"projectid": cn.ProjectID,
+			}
+		},
+	},
+	{
+		name: "dos",
+		fields: map[string]schema.ValueType{
+			"endpoint":  schema.TypeString,
+			"space":     schema.TypeString,
+			"accesskey": schema.TypeString,
+			"secretkey": schema.TypeString,
+		},
+		required: []string{"endpoint", "space", "accesskey", "secretkey"},
+		secrets:  []string{"accesskey", "secretkey"},
+		request: func(config map[string]interface{}, secret func(string) string) interface{} {
+			return dosConfig{
+				Integration: "dos",
+				Space:       config["space"].(string),
+				Endpoint:    config["endpoint"].(string),
+				AccessKey:   secret("accesskey"),
+				SecretKey:   secret("secretkey"),
+			}
+		},
+		response: func(cn archiveResponse) map[string]interface{} {
+			return map[string]interface{}{
+				"space":    cn.Space,
+				"endpoint": cn.Endpoint,
+			}
+		},
+	},
+	{
+		name: "swift",
+		fields: map[string]schema.ValueType{
+			"authurl":    schema.TypeString,
+			"expires":    schema.TypeInt,
+			"username":   schema.TypeString,
+			"password":   schema.TypeString,
+			"tenantname": schema.TypeString,
+		},
+		required: []string{"authurl", "username", "password", "tenantname"},
+		secrets:  []string{"password"},
+		request: func(config map[string]interface{}, secret func(string) string) interface{} {
+			return swiftConfig{
+				Integration: "swift",
+				AuthURL:     config["authurl"].(string),
+				Expires:     config["expires"].(int),
+				Username:    config["username"].(string),
+				Password:    secret("password"),
+				TenantName:  config["tenantname"].(string),
+			}
+		},
+		response: func(cn archiveResponse) map[string]interface{} {
+			return map[string]interface{}{
+				"authurl":    cn.AuthURL,
+				"expires":    cn.Expires,
+				"username":   cn.Username,
+				"tenantname": cn.TenantName,
+			}
+		},
+	},
+	{
+		// Any store implementing the S3 API, such as MinIO, Wasabi or Ceph
+		name: "s3_compatible",
+		fields: map[string]schema.ValueType{
+			"bucket":         schema.TypeString,
+			"endpoint":       schema.TypeString,
+			"region":         schema.TypeString,
+			"forcepathstyle": schema.TypeBool,
+			"accesskey":      schema.TypeString,
+			"secretkey":      schema.TypeString,
+		},
+		required: []string{"bucket", "endpoint", "accesskey", "secretkey"},
+		secrets:  []string{"accesskey", "secretkey"},
+		validators: map[string]schema.SchemaValidateFunc{
+			"endpoint": validation.IsURLWithHTTPorHTTPS,
+		},
+		request: func(config map[string]interface{}, secret func(string) string) interface{} {
+			return s3CompatibleConfig{
+				Integration:    "s3_compatible",
+				Bucket:         config["bucket"].(string),
+				Endpoint:       config["endpoint"].(string),
+				Region:         config["region"].(string),
+				ForcePathStyle: config["forcepathstyle"].(bool),
+				AccessKey:      secret("accesskey"),
+				SecretKey:      secret("secretkey"),
+			}
+		},
+		response: func(cn archiveResponse) map[string]interface{} {
+			return map[string]interface{}{
+				"bucket":         cn.Bucket,
+				"endpoint":       cn.Endpoint,
+				"region":         cn.Region,
+				"forcepathstyle": cn.ForcePathStyle,
+			}
+		},
+	},
+}
+
+func getArchiveIntegration(name string) *archiveIntegration {
+	for _, integration := range archiveIntegrations {
+		if integration.name == name {
+			return integration
+		}
+	}
+	return nil
+}
+
+func archiveIntegrationNames() []string {
+	names := make([]string, 0, len(archiveIntegrations))
+	for _, integration := range archiveIntegrations {
+		names = append(names, integration.name)
+	}
+	return names
+}
+
+func (integration *archiveIntegration) configKey() string {
+	return fmt.Sprintf("%s_config", integration.name)
+}
+
+// configSchema builds the schema of the `<name>_config` block
+func (integration *archiveIntegration) configSchema() *schema.Schema {
+	fields := map[string]*schema.Schema{}
+	for field, valueType := range integration.fields {
+		fields[field] = &schema.Schema{Type: valueType, Optional: true}
+	}
+	for _, field := range integration.required {
+		fields[field].Optional = false
+		fields[field].Required = true
+	}
+	for _, field := range integration.secrets {
+		fields[field].Sensitive = true
+		fields[field].DiffSuppressFunc = suppressSecretDiff
+	}
+	for field, validate := range integration.validators {
+		fields[field].ValidateFunc = validate
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+// dataSourceSchema builds the computed `<name>_config` block of the data
+// source, which leaves the secrets out
+func (integration *archiveIntegration) dataSourceSchema() *schema.Schema {
+	fields := map[string]*schema.Schema{}
+	for field, valueType := range integration.fields {
+		fields[field] = &schema.Schema{Type: valueType, Computed: true}
+	}
+	for _, field := range integration.secrets {
+		delete(fields, field)
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
 }
 
 func generateArchiveConfig(d *schema.ResourceData) (interface{}, error) {
-	integration := d.Get("integration").(string)
-	configKey := fmt.Sprintf(`%s_config`, integration)
+	name := d.Get("integration").(string)
+	integration := getArchiveIntegration(name)
+	if integration == nil {
+		return nil, fmt.Errorf("unsupported archive integration: %s", name)
+	}
+
+	configKey := integration.configKey()
 	configRaw := d.Get(configKey).([]interface{})
-	if len(configRaw) == 0 {
-		err := fmt.Errorf("expected %s_config for integration: %s", integration, integration)
+	if len(configRaw) == 0 || configRaw[0] == nil {
+		err := fmt.Errorf("expected %s for integration: %s", configKey, name)
 		return nil, err
 	}
 	config := configRaw[0].(map[string]interface{})
 
-	if integration == "ibm" {
-		ibm := ibmConfig{
-			Bucket:             config["bucket"].(string),
-			Endpoint:           config["endpoint"].(string),
-			APIKey:             config["apikey"].(string),
-			ResourceInstanceID: config["resourceinstanceid"].(string),
-		}
-		return struct {
-			Integration string `json:"integration"`
-			ibmConfig
-		}{integration, ibm}, nil
-	} else if integration == "s3" {
-		s3 := s3Config{
-			Bucket: config["bucket"].(string),
-		}
-		return struct {
-			Integration string `json:"integration"`
-			s3Config
-		}{integration, s3}, nil
-	} else if integration == "azblob" {
-		azblob := azblobConfig{
-			AccountName: config["accountname"].(string),
-			AccountKey:  config["accountkey"].(string),
-		}
-		return struct {
-			Integration string `json:"integration"`
-			azblobConfig
-		}{integration, azblob}, nil
-	} else if integration == "gcs" {
-		gcs := gcsConfig{
-			Bucket:    config["bucket"].(string),
-			ProjectID: config["projectid"].(string),
-		}
-		return struct {
-			Integration string `json:"integration"`
-			gcsConfig
-		}{integration, gcs}, nil
-	} else if integration == "dos" {
-		dos := dosConfig{
-			Space:     config["space"].(string),
-			Endpoint:  config["endpoint"].(string),
-			AccessKey: config["accesskey"].(string),
-			SecretKey: config["secretkey"].(string),
+	return integration.request(config, func(field string) string {
+		return secretFromConfig(d, configKey, field)
+	}), nil
+}
+
+// setArchiveConfig maps the remote configuration to the state. Credentials are
+// never read back from the API, the state keeps a hash of the applied ones.
+func setArchiveConfig(cn archiveResponse, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	appendError(d.Set("integration", cn.Integration), &diags)
+
+	integration := getArchiveIntegration(cn.Integration)
+	if integration == nil {
+		log.Printf("[WARN] Ignoring the configuration of unsupported archive integration %q", cn.Integration)
+		return diags
+	}
+
+	config := integration.response(cn)
+	for _, field := range integration.secrets {
+		stored, err := storedSecret(d, fmt.Sprintf("%s.0.%s", integration.configKey(), field))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Cannot hash the %s of the archive configuration", field),
+				Detail:   err.Error(),
+			})
+			return diags
 		}
-		return struct {
-			Integration string `json:"integration"`
-			dosConfig
-		}{integration, dos}, nil
-	} else {
-		swift := swiftConfig{
-			AuthURL:    config["authurl"].(string),
-			Expires:    config["expires"].(int),
-			Username:   config["username"].(string),
-			Password:   config["password"].(string),
-			TenantName: config["tenantname"].(string),
+		config[field] = stored
+	}
+	appendError(d.Set(integration.configKey(), []interface{}{config}), &diags)
+
+	// Only the block of the current integration is kept
+	for _, other := range archiveIntegrations {
+		if other != integration {
+			appendError(d.Set(other.configKey(), nil), &diags)
 		}
-		return struct {
-			Integration string `json:"integration"`
-			swiftConfig
-		}{integration, swift}, nil
 	}
+
+	return diags
 }
 
-func setArchiveConfig(cn archiveResponse, d *schema.ResourceData, diags diag.Diagnostics) {
-	integration := cn.Integration
-	appendError(d.Set("integration", integration), &diags)
-
-	switch integration {
-	case "ibm":
-		ibmConfig := make(map[string]interface{})
-		ibmConfig["bucket"] = cn.Bucket
-		ibmConfig["endpoint"] = cn.Endpoint
-		ibmConfig["apikey"] = cn.APIKey
-		ibmConfig["resourceinstanceid"] = cn.ResourceInstanceID
-		appendError(d.Set("ibm_config", []interface{}{ibmConfig}), &diags)
-	case "s3":
-		s3Config := make(map[string]interface{})
-		s3Config["bucket"] = cn.Bucket
-		appendError(d.Set("s3_config", []interface{}{s3Config}), &diags)
-	case "azblob":
-		azblobConfig := make(map[string]interface{})
-		azblobConfig["accountname"] = cn.AccountName
-		azblobConfig["accountkey"] = cn.AccountKey
-		appendError(d.Set("azblob_config", []interface{}{azblobConfig}), &diags)
-	case "gcs":
-		gcsConfig := make(map[string]interface{})
-		gcsConfig["bucket"] = cn.Bucket
-		gcsConfig["projectid"] = cn.ProjectID
-		appendError(d.Set("gcs_config", []interface{}{gcsConfig}), &diags)
-	case "dos":
-		dosConfig := make(map[string]interface{})
-		dosConfig["space"] = cn.Space
-		dosConfig["endpoint"] = cn.Endpoint
-		dosConfig["accesskey"] = cn.AccessKey
-		dosConfig["secretkey"] = cn.SecretKey
-		appendError(d.Set("dos_config", []interface{}{dosConfig}), &diags)
-	case "swift":
-		swiftConfig := make(map[string]interface{})
-		swiftConfig["authurl"] = cn.AuthURL
-		swiftConfig["expires"] = cn.Expires
-		swiftConfig["username"] = cn.Username
-		swiftConfig["password"] = cn.Password
-		swiftConfig["tenantname"] = cn.TenantName
-		appendError(d.Set("swift_config", []interface{}{swiftConfig}), &diags)
+// customizeArchiveDiff requires the block matching the integration, and only
+// that one, to be set
+func customizeArchiveDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	name := d.Get("integration").(string)
+	integration := getArchiveIntegration(name)
+	if integration == nil {
+		// Reported by the validation of the integration, or not known yet
+		return nil
+	}
+
+	if len(d.Get(integration.configKey()).([]interface{})) == 0 {
+		return fmt.Errorf("the %s integration requires a %s block", name, integration.configKey())
 	}
+	for _, other := range archiveIntegrations {
+		if other != integration && len(d.Get(other.configKey()).([]interface{})) > 0 {
+			return fmt.Errorf("%s cannot be set when the integration is %s, only %s is allowed", other.configKey(), name, integration.configKey())
+		}
+	}
+	return nil
 }
 
 func resourceArchiveConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -228,8 +474,7 @@ func resourceArchiveConfigRead(ctx context.Context, d *schema.ResourceData, m in
 		return diags
 	}
 
-	setArchiveConfig(c, d, diags)
-	return diags
+	return setArchiveConfig(c, d)
 }
 
 func resourceArchiveConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -280,161 +525,35 @@ func resourceArchiveConfigDelete(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceArchiveConfig() *schema.Resource {
-	validIntegrations := []string{"ibm", "s3", "azblob", "gcs", "dos", "swift"}
+	validIntegrations := archiveIntegrationNames()
+
+	resourceSchema := map[string]*schema.Schema{
+		"integration": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+				v := val.(string)
+				if getArchiveIntegration(v) == nil {
+					errs = append(errs, fmt.Errorf("%q must be one of %v, got: %s", key, validIntegrations, v))
+				}
+				return
+			},
+		},
+	}
+	for _, integration := range archiveIntegrations {
+		resourceSchema[integration.configKey()] = integration.configSchema()
+	}
 
 	return &schema.Resource{
 		CreateContext: resourceArchiveConfigCreate,
 		ReadContext:   resourceArchiveConfigRead,
 		UpdateContext: resourceArchiveConfigUpdate,
 		DeleteContext: resourceArchiveConfigDelete,
+		CustomizeDiff: customizeArchiveDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"integration": {
-				Type:     schema.TypeString,
-				Required: true,
-				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-					valid := false
-					v := val.(string)
-					for _, str := range validIntegrations {
-						if v == str {
-							valid = true
-						}
-					}
-					if !valid {
-						errs = append(errs, fmt.Errorf("%q must be one of %v, got: %s", key, validIntegrations, v))
-					}
-					return
-				},
-			},
-			"ibm_config": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"bucket": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"endpoint": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"apikey": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"resourceinstanceid": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
-			"s3_config": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"bucket": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
-			"azblob_config": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"accountname": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"accountkey": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
-			"gcs_config": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"bucket": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"projectid": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
-			"dos_config": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"endpoint": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"space": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"accesskey": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"secretkey": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
-			"swift_config": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"authurl": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"expires": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
-						"username": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"password": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"tenantname": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
-			},
-		},
+		Schema: resourceSchema,
 	}
 }
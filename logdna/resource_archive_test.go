@@ -1,12 +1,17 @@
 package logdna
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 var s3Bucket = os.Getenv("S3_BUCKET")
@@ -57,7 +62,7 @@ func TestArchiveConfig_expectInvalidIntegrationError(t *testing.T) {
 				Config: testArchiveConfig(`
 					integration = "invalid"
 				`, apiHostUrl),
-				ExpectError: regexp.MustCompile(`"integration" must be one of \[ibm s3 azblob gcs dos swift\]`),
+				ExpectError: regexp.MustCompile(`"integration" must be one of \[ibm s3 azblob gcs dos swift s3_compatible\]`),
 			},
 		},
 	})
@@ -138,3 +143,114 @@ func testArchiveConfig(fields string, url string) string {
 		}
 	`, serviceKey, uc, fields)
 }
+
+func TestArchiveConfig_generateArchiveConfig(t *testing.T) {
+	cases := map[string]struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		"ibm": {
+			config:   map[string]interface{}{"bucket": "b", "endpoint": "e", "apikey": "k", "resourceinstanceid": "r"},
+			expected: `{"integration":"ibm","bucket":"b","endpoint":"e","apikey":"k","resourceinstanceid":"r"}`,
+		},
+		"s3": {
+			config:   map[string]interface{}{"bucket": "b"},
+			expected: `{"integration":"s3","bucket":"b"}`,
+		},
+		"azblob": {
+			config:   map[string]interface{}{"accountname": "n", "accountkey": "k"},
+			expected: `{"integration":"azblob","accountname":"n","accountkey":"k"}`,
+		},
+		"gcs": {
+			config:   map[string]interface{}{"bucket": "b", "projectid": "p"},
+			expected: `{"integration":"gcs","bucket":"b","projectid":"p"}`,
+		},
+		"dos": {
+			config:   map[string]interface{}{"space": "s", "endpoint": "e", "accesskey": "a", "secretkey": "k"},
+			expected: `{"integration":"dos","space":"s","endpoint":"e","accesskey":"a","secretkey":"k"}`,
+		},
+		"swift": {
+			config:   map[string]interface{}{"authurl": "u", "expires": 7, "username": "n", "password": "p", "tenantname": "t"},
+			expected: `{"integration":"swift","authurl":"u","expires":7,"username":"n","password":"p","tenantname":"t"}`,
+		},
+		"s3_compatible": {
+			config: map[string]interface{}{
+				"bucket": "b", "endpoint": "https://minio.example.org:9000", "forcepathstyle": true, "accesskey": "a", "secretkey": "k",
+			},
+			expected: `{"integration":"s3_compatible","bucket":"b","endpoint":"https://minio.example.org:9000","forcepathstyle":true,"accesskey":"a","secretkey":"k"}`,
+		},
+	}
+
+	assert.Len(t, cases, len(archiveIntegrations), "Every integration is covered")
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceArchiveConfig().Schema, map[string]interface{}{
+				"integration":                  name,
+				fmt.Sprintf("%s_config", name): []interface{}{c.config},
+			})
+			payload, err := generateArchiveConfig(d)
+			assert.Nil(t, err, "No errors")
+
+			body, err := json.Marshal(payload)
+			assert.Nil(t, err, "No errors")
+			assert.JSONEq(t, c.expected, string(body))
+		})
+	}
+
+	t.Run("Requires the block of the integration", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceArchiveConfig().Schema, map[string]interface{}{
+			"integration": "gcs",
+		})
+		_, err := generateArchiveConfig(d)
+		assert.EqualError(t, err, "expected gcs_config for integration: gcs")
+	})
+}
+
+func TestArchiveConfig_setArchiveConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceArchiveConfig().Schema, map[string]interface{}{
+		"integration": "dos",
+		"dos_config": []interface{}{map[string]interface{}{
+			"space": "s", "endpoint": "e", "accesskey": "a", "secretkey": "k",
+		}},
+	})
+
+	// The API masks the credentials
+	diags := setArchiveConfig(archiveResponse{
+		Integration: "dos",
+		Space:       "s2",
+		Endpoint:    "e",
+		AccessKey:   "****",
+		SecretKey:   "****",
+	}, d)
+	assert.Empty(t, diags, "No errors")
+	assert.Equal(t, "s2", d.Get("dos_config.0.space"))
+	assert.True(t, secretMatches(d.Get("dos_config.0.accesskey").(string), "a"), "The applied access key is hashed")
+	assert.True(t, secretMatches(d.Get("dos_config.0.secretkey").(string), "k"), "The applied secret key is hashed")
+
+	diags = setArchiveConfig(archiveResponse{Integration: "unknown"}, d)
+	assert.Empty(t, diags, "Unsupported integrations are ignored")
+	assert.Equal(t, "unknown", d.Get("integration"))
+}
+
+func TestArchiveConfig_customizeArchiveDiff(t *testing.T) {
+	diff := func(config map[string]interface{}) error {
+		_, err := resourceArchiveConfig().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+		return err
+	}
+
+	assert.Nil(t, diff(map[string]interface{}{
+		"integration": "s3",
+		"s3_config":   []interface{}{map[string]interface{}{"bucket": "b"}},
+	}), "No errors")
+
+	assert.EqualError(t, diff(map[string]interface{}{
+		"integration": "gcs",
+		"s3_config":   []interface{}{map[string]interface{}{"bucket": "b"}},
+	}), "the gcs integration requires a gcs_config block")
+
+	assert.EqualError(t, diff(map[string]interface{}{
+		"integration": "s3",
+		"s3_config":   []interface{}{map[string]interface{}{"bucket": "b"}},
+		"gcs_config":  []interface{}{map[string]interface{}{"bucket": "b", "projectid": "p"}},
+	}), "gcs_config cannot be set when the integration is s3, only s3_config is allowed")
+}
@@ -0,0 +1,292 @@
+package logdna
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var boardTimeRangeExp = regexp.MustCompile(`^[1-9][0-9]*[mhdw]$`)
+var plotAggregations = []string{"count", "sum", "avg", "min", "max", "distinct"}
+
+func resourceBoardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+
+	board := boardRequest{}
+
+	if diags = board.CreateRequestBody(d); diags.HasError() {
+		return diags
+	}
+	names, catDiags := resolveCategoryIds(pc, d, "boards")
+	diags = append(diags, catDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if names != nil {
+		board.Category = names
+	}
+
+	req := newRequestConfig(
+		pc,
+		"POST",
+		"/v1/config/board",
+		board,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createdBoard := boardResponse{}
+	err = json.Unmarshal(body, &createdBoard)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[DEBUG] After %s board, the created board is %+v", req.method, createdBoard)
+
+	d.SetId(createdBoard.BoardID)
+
+	return resourceBoardRead(ctx, d, m)
+}
+
+func resourceBoardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	boardID := d.Id()
+
+	req := newRequestConfig(
+		pc,
+		"GET",
+		fmt.Sprintf("/v1/config/board/%s", boardID),
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+
+	log.Printf("[DEBUG] GET board raw response body %s\n", body)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot read the remote board resource",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	board := boardResponse{}
+	err = json.Unmarshal(body, &board)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot unmarshal response from the remote board resource",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	log.Printf("[DEBUG] The GET board structure is as follows: %+v\n", board)
+
+	// Top level keys can be set directly
+	appendError(d.Set("name", board.Name), &diags)
+	if diags = append(diags, setCategories(pc, d, "boards", board.Category)...); diags.HasError() {
+		return diags
+	}
+
+	appendError(d.Set("graph", board.MapGraphsToSchema()), &diags)
+
+	return diags
+}
+
+func resourceBoardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+	boardID := d.Id()
+	board := boardRequest{}
+
+	if diags = board.CreateRequestBody(d); diags.HasError() {
+		return diags
+	}
+	names, catDiags := resolveCategoryIds(pc, d, "boards")
+	diags = append(diags, catDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if names != nil {
+		board.Category = names
+	}
+
+	req := newRequestConfig(
+		pc,
+		"PUT",
+		fmt.Sprintf("/v1/config/board/%s", boardID),
+		board,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s %s SUCCESS. Remote resource updated.", req.method, req.apiURL)
+
+	return resourceBoardRead(ctx, d, m)
+}
+
+func resourceBoardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+	boardID := d.Id()
+
+	req := newRequestConfig(
+		pc,
+		"DELETE",
+		fmt.Sprintf("/v1/config/board/%s", boardID),
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s board %s", req.method, req.apiURL, body)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// customizeBoardDiff checks that every aggregation other than `count` has a
+// field to aggregate over
+func customizeBoardDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for i, g := range d.Get("graph").([]interface{}) {
+		graph, _ := g.(map[string]interface{})
+		if graph == nil {
+			continue
+		}
+		for j, p := range graph["plot"].([]interface{}) {
+			plot, _ := p.(map[string]interface{})
+			if plot == nil {
+				continue
+			}
+			key := fmt.Sprintf("graph.%d.plot.%d", i, j)
+			if !d.NewValueKnown(key+".aggregation") || !d.NewValueKnown(key+".field") {
+				continue
+			}
+			if aggregation := plot["aggregation"].(string); aggregation != "count" && plot["field"].(string) == "" {
+				return fmt.Errorf("%s: the %s aggregation requires a field", key, aggregation)
+			}
+		}
+	}
+	return nil
+}
+
+func resourceBoard() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBoardCreate,
+		ReadContext:   resourceBoardRead,
+		UpdateContext: resourceBoardUpdate,
+		DeleteContext: resourceBoardDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importBoardState,
+		},
+		CustomizeDiff: customizeBoardDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"categories": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"category_ids"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"category_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCategoryIdOfType("boards"),
+				},
+				ConflictsWith: []string{"categories"},
+			},
+			"graph": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"graphid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "line",
+							ValidateFunc: validation.StringInSlice([]string{"line", "area", "bar", "table"}, false),
+						},
+						"time_range": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "1h",
+							ValidateFunc: validation.StringMatch(boardTimeRangeExp, "must be a duration such as 15m, 1h, 7d or 2w"),
+						},
+						"plot": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"query": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ValidateDiagFunc: validateQuery,
+										DiffSuppressFunc: suppressEquivalentQuery,
+									},
+									"aggregation": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "count",
+										ValidateFunc: validation.StringInSlice(plotAggregations, false),
+									},
+									"field": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"group_by": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"label": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package logdna
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testBoardConfig(body string) string {
+	return fmt.Sprintf(`%s
+resource "logdna_category" "boards" {
+	name = "DemoBoardCategory"
+	type = "boards"
+}
+
+resource "logdna_board" "new" {
+%s
+}`, fmtProviderBlock(globalPcArgs...), body)
+}
+
+func TestBoard_ErrorsResourceFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBoardConfig(`
+	graph {
+		title = "test"
+		plot {
+			query = "app:api"
+		}
+	}`),
+				ExpectError: regexp.MustCompile(`The argument "name" is required, but no definition was found.`),
+			},
+			{
+				Config: testBoardConfig(`
+	name = "test"
+	graph {
+		title      = "test"
+		time_range = "1 hour"
+		plot {
+			query = "app:api"
+		}
+	}`),
+				ExpectError: regexp.MustCompile("must be a duration such as 15m, 1h, 7d or 2w"),
+			},
+			{
+				Config: testBoardConfig(`
+	name = "test"
+	graph {
+		title = "test"
+		plot {
+			query       = "app:api"
+			aggregation = "avg"
+		}
+	}`),
+				ExpectError: regexp.MustCompile("graph.0.plot.0: the avg aggregation requires a field"),
+			},
+			{
+				Config: testBoardConfig(`
+	name = "test"
+	graph {
+		title = "test"
+		plot {
+			query = "app:api AND"
+		}
+	}`),
+				ExpectError: regexp.MustCompile("Invalid search query"),
+			},
+			{
+				Config: testBoardConfig(`
+	name         = "test"
+	category_ids = ["views:abc123"]`),
+				ExpectError: regexp.MustCompile("must reference a category of type boards, got: views"),
+			},
+		},
+	})
+}
+
+func TestBoard_Basic(t *testing.T) {
+	iniCfg := testBoardConfig(`
+	name         = "test"
+	category_ids = [logdna_category.boards.id]
+
+	graph {
+		title = "Errors"
+		plot {
+			query    = "level:error"
+			group_by = ["app"]
+		}
+	}`)
+
+	updCfg := testBoardConfig(`
+	name         = "test2"
+	category_ids = [logdna_category.boards.id]
+
+	graph {
+		title      = "Errors"
+		type       = "bar"
+		time_range = "24h"
+		plot {
+			query    = "level:error"
+			group_by = ["app", "host"]
+		}
+	}
+
+	graph {
+		title = "Latency"
+		plot {
+			query       = "app:api"
+			aggregation = "avg"
+			field       = "response_time"
+			label       = "API"
+		}
+		plot {
+			query       = "app:worker"
+			aggregation = "max"
+			field       = "response_time"
+			label       = "Worker"
+		}
+	}`)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: iniCfg,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("board", "new"),
+					resource.TestCheckResourceAttr("logdna_board.new", "name", "test"),
+					resource.TestCheckResourceAttr("logdna_board.new", "category_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("logdna_board.new", "category_ids.*", "logdna_category.boards", "id"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.#", "1"),
+					resource.TestCheckResourceAttrSet("logdna_board.new", "graph.0.graphid"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.type", "line"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.time_range", "1h"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.plot.#", "1"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.plot.0.query", "level:error"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.plot.0.aggregation", "count"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.plot.0.group_by.0", "app"),
+				),
+			},
+			{
+				Config: updCfg,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("board", "new"),
+					resource.TestCheckResourceAttr("logdna_board.new", "name", "test2"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.#", "2"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.type", "bar"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.time_range", "24h"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.0.plot.0.group_by.#", "2"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.1.title", "Latency"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.1.plot.#", "2"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.1.plot.0.aggregation", "avg"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.1.plot.0.field", "response_time"),
+					resource.TestCheckResourceAttr("logdna_board.new", "graph.1.plot.1.label", "Worker"),
+				),
+			},
+			{
+				ResourceName:            "logdna_board.new",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"category_ids", "categories"},
+			},
+		},
+	})
+}
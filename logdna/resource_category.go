@@ -172,6 +172,122 @@ func parseCategoryId(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// validateCategoryIdOfType checks that a `Type:Id` category ID references a
+// category of the given type
+func validateCategoryIdOfType(categoryType string) schema.SchemaValidateFunc {
+	return func(val interface{}, key string) (warns []string, errs []error) {
+		typ, _, err := parseCategoryId(val.(string))
+		if err != nil {
+			errs = append(errs, err)
+		} else if typ != categoryType {
+			errs = append(errs, fmt.Errorf("%q must reference a category of type %s, got: %s", key, categoryType, typ))
+		}
+		return
+	}
+}
+
+func listCategories(pc *providerConfig, categoryType string) ([]categoryResponse, error) {
+	categories := []categoryResponse{}
+	uri := fmt.Sprintf("/v1/config/categories/%s", categoryType)
+	if err := listRemote(pc, uri, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// categoryIdsToNames resolves `Type:Id` category IDs to the category names
+// expected by the APIs of the categorized objects
+func categoryIdsToNames(categories []categoryResponse, ids []string) ([]string, error) {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		_, categoryId, err := parseCategoryId(id)
+		if err != nil {
+			return nil, err
+		}
+
+		name := ""
+		for _, category := range categories {
+			if category.Id == categoryId {
+				name = category.Name
+				break
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("category %s does not exist", id)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// categoryNamesToIds maps category names back to `Type:Id` category IDs.
+// Names are compared case-insensitively and unknown names are dropped.
+func categoryNamesToIds(categories []categoryResponse, categoryType string, names []string) []string {
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, category := range categories {
+			if strings.EqualFold(category.Name, name) {
+				ids = append(ids, fmt.Sprintf("%s:%s", categoryType, category.Id))
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// resolveCategoryIds returns the names of the categories referenced by the
+// category_ids of a categorized resource, or nil when none are configured
+func resolveCategoryIds(pc *providerConfig, d *schema.ResourceData, categoryType string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ids := setToStrings(d.Get("category_ids").(*schema.Set))
+	if len(ids) == 0 {
+		return nil, diags
+	}
+
+	categories, err := listCategories(pc, categoryType)
+	if err != nil {
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot resolve category_ids into category names",
+			Detail:   err.Error(),
+		})
+	}
+
+	names, err := categoryIdsToNames(categories, ids)
+	if err != nil {
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot resolve category_ids into category names",
+			Detail:   err.Error(),
+		})
+	}
+	return names, diags
+}
+
+// setCategories stores the category names returned by the API either in
+// `categories`, or mapped back to IDs in `category_ids` when those are used.
+// This way references to logdna_category resources survive renames.
+func setCategories(pc *providerConfig, d *schema.ResourceData, categoryType string, names []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.Get("category_ids").(*schema.Set).Len() == 0 {
+		appendError(d.Set("categories", names), &diags)
+		return diags
+	}
+
+	categories, err := listCategories(pc, categoryType)
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot read the remote category resources",
+			Detail:   err.Error(),
+		})
+	}
+	appendError(d.Set("category_ids", categoryNamesToIds(categories, categoryType, names)), &diags)
+	return diags
+}
+
 func resourceCategory() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCategoryCreate,
@@ -179,21 +295,7 @@ func resourceCategory() *schema.Resource {
 		ReadContext:   resourceCategoryRead,
 		DeleteContext: resourceCategoryDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				categoryType, categoryId, err := parseCategoryId(d.Id())
-
-				if err != nil {
-					return nil, err
-				}
-
-				if err := d.Set("type", categoryType); err != nil {
-					return nil, err
-				}
-
-				d.SetId(fmt.Sprintf("%s:%s", categoryType, categoryId))
-
-				return []*schema.ResourceData{d}, nil
-			},
+			StateContext: importCategoryState,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -6,8 +6,33 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestCategory_mapCategoryIds(t *testing.T) {
+	assert := assert.New(t)
+	categories := []categoryResponse{
+		{Id: "1", Name: "Production", Type: "views"},
+		{Id: "2", Name: "Staging", Type: "views"},
+	}
+
+	t.Run("Resolves category IDs to names", func(t *testing.T) {
+		names, err := categoryIdsToNames(categories, []string{"views:2", "views:1"})
+		assert.Nil(err, "No errors")
+		assert.Equal([]string{"Staging", "Production"}, names)
+	})
+
+	t.Run("Errors when a category ID does not exist", func(t *testing.T) {
+		_, err := categoryIdsToNames(categories, []string{"views:3"})
+		assert.EqualError(err, "category views:3 does not exist")
+	})
+
+	t.Run("Maps category names back to IDs", func(t *testing.T) {
+		ids := categoryNamesToIds(categories, "views", []string{"production", "Deleted", "Staging"})
+		assert.Equal([]string{"views:1", "views:2"}, ids)
+	})
+}
+
 func TestCategory_ErrorProviderUrl(t *testing.T) {
 	pcArgs := []string{serviceKey, "https://api.logdna.co"}
 	catArgs := map[string]string{
@@ -0,0 +1,382 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// exclusionSetKind describes one of the exclusion rule lists of the account.
+// Ingestion rules have an extra `indexonly` setting.
+type exclusionSetKind struct {
+	name      string
+	id        string
+	url       string
+	indexOnly bool
+}
+
+var ingestionExclusionSet = exclusionSetKind{
+	name:      "ingestion exclusion",
+	id:        "ingestion_exclusions",
+	url:       baseIngestionExclusionUrl,
+	indexOnly: true,
+}
+
+var streamExclusionSet = exclusionSetKind{
+	name: "stream exclusion",
+	id:   "stream_exclusions",
+	url:  "/v1/config/stream/exclusions",
+}
+
+// exclusionChanges lists the requests needed to turn the remote rules into
+// the declared ones
+type exclusionChanges struct {
+	create []ingestionExclusionRule
+	update []exclusionUpdate
+	remove []string
+}
+
+type exclusionUpdate struct {
+	id   string
+	rule ingestionExclusionRule
+}
+
+// matchExclusionRules returns the ID of the remote rule matching each
+// declared rule, or an empty string. A rule is matched by its ID in the state
+// while its title is unchanged, then by title, and finally by its ID again so
+// that a renamed rule keeps its ID.
+func matchExclusionRules(current []ingestionExclusionRule, desired []ingestionExclusionRule) []string {
+	remote := map[string]ingestionExclusionRule{}
+	for _, rule := range current {
+		remote[rule.ID] = rule
+	}
+	claimed := map[string]bool{}
+	matches := make([]string, len(desired))
+	claim := func(i int, id string) {
+		matches[i] = id
+		claimed[id] = true
+	}
+
+	for i, rule := range desired {
+		if existing, ok := remote[rule.ID]; ok && rule.ID != "" && existing.Title == rule.Title {
+			claim(i, rule.ID)
+		}
+	}
+	for i, rule := range desired {
+		if matches[i] != "" {
+			continue
+		}
+		for _, existing := range current {
+			if !claimed[existing.ID] && existing.Title == rule.Title {
+				claim(i, existing.ID)
+				break
+			}
+		}
+	}
+	for i, rule := range desired {
+		if _, ok := remote[rule.ID]; ok && rule.ID != "" && matches[i] == "" && !claimed[rule.ID] {
+			claim(i, rule.ID)
+		}
+	}
+
+	return matches
+}
+
+// planExclusionChanges creates the declared rules without a remote match and
+// updates the others. Remote rules that are not matched, including duplicated
+// titles, are removed.
+func planExclusionChanges(kind exclusionSetKind, current []ingestionExclusionRule, desired []ingestionExclusionRule) *exclusionChanges {
+	changes := &exclusionChanges{}
+
+	remote := map[string]ingestionExclusionRule{}
+	for _, rule := range current {
+		remote[rule.ID] = rule
+	}
+	matches := matchExclusionRules(current, desired)
+	claimed := map[string]bool{}
+	for _, id := range matches {
+		claimed[id] = true
+	}
+
+	for i, rule := range desired {
+		if matches[i] == "" {
+			rule.ID = ""
+			changes.create = append(changes.create, rule)
+			continue
+		}
+		if !sameExclusionRule(kind, remote[matches[i]], rule) {
+			rule.ID = ""
+			changes.update = append(changes.update, exclusionUpdate{id: matches[i], rule: rule})
+		}
+	}
+
+	for _, rule := range current {
+		if !claimed[rule.ID] {
+			changes.remove = append(changes.remove, rule.ID)
+		}
+	}
+
+	return changes
+}
+
+func sameExclusionRule(kind exclusionSetKind, a ingestionExclusionRule, b ingestionExclusionRule) bool {
+	return a.Title == b.Title &&
+		a.Active == b.Active &&
+		sameStrings(a.Apps, b.Apps) &&
+		sameStrings(a.Hosts, b.Hosts) &&
+		equivalentQueries(a.Query, b.Query) &&
+		(!kind.indexOnly || a.IndexOnly == b.IndexOnly)
+}
+
+// request returns the payload for a rule, without `indexonly` for streams
+func (kind exclusionSetKind) request(rule ingestionExclusionRule) interface{} {
+	if kind.indexOnly {
+		return rule
+	}
+	return rule.exclusionRule
+}
+
+func exclusionRulesFromSchema(kind exclusionSetKind, d *schema.ResourceData) []ingestionExclusionRule {
+	rules := []ingestionExclusionRule{}
+	for _, r := range d.Get("rule").([]interface{}) {
+		rule := r.(map[string]interface{})
+		ex := ingestionExclusionRule{
+			exclusionRule: exclusionRule{
+				ID:     rule["id"].(string),
+				Title:  rule["title"].(string),
+				Active: rule["active"].(bool),
+				Apps:   listToStrings(rule["apps"].([]interface{})),
+				Hosts:  listToStrings(rule["hosts"].([]interface{})),
+				Query:  rule["query"].(string),
+			},
+		}
+		if kind.indexOnly {
+			ex.IndexOnly = rule["indexonly"].(bool)
+		}
+		rules = append(rules, ex)
+	}
+	return rules
+}
+
+func (kind exclusionSetKind) apply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+
+	current := []ingestionExclusionRule{}
+	if err := listRemote(pc, kind.url, &current); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Cannot list the remote %s resources", kind.name),
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	changes := planExclusionChanges(kind, current, exclusionRulesFromSchema(kind, d))
+	log.Printf("[DEBUG] Planned %s changes: %+v", kind.name, changes)
+
+	for _, rule := range changes.create {
+		req := newRequestConfig(pc, "POST", kind.url, kind.request(rule))
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, update := range changes.update {
+		req := newRequestConfig(pc, "PATCH", fmt.Sprintf("%s/%s", kind.url, update.id), kind.request(update.rule))
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, id := range changes.remove {
+		req := newRequestConfig(pc, "DELETE", fmt.Sprintf("%s/%s", kind.url, id), nil)
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s %s %s", req.method, req.apiURL, kind.name, body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(kind.id)
+
+	return kind.read(ctx, d, m)
+}
+
+func (kind exclusionSetKind) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+
+	rules := []ingestionExclusionRule{}
+	if err := listRemote(pc, kind.url, &rules); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Cannot read the remote %s resources", kind.name),
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	log.Printf("[DEBUG] The GET %s structure is as follows: %+v\n", kind.name, rules)
+
+	// Keep the declared order, remote rules that are not declared come last
+	ordered := []*ingestionExclusionRule{}
+	placed := map[string]bool{}
+	for _, id := range matchExclusionRules(rules, exclusionRulesFromSchema(kind, d)) {
+		for i := range rules {
+			if id != "" && rules[i].ID == id {
+				ordered = append(ordered, &rules[i])
+				placed[id] = true
+			}
+		}
+	}
+	for i := range rules {
+		if !placed[rules[i].ID] {
+			ordered = append(ordered, &rules[i])
+		}
+	}
+
+	items := []interface{}{}
+	ids := []string{}
+	for _, rule := range ordered {
+		item := map[string]interface{}{
+			"id":     rule.ID,
+			"title":  rule.Title,
+			"active": rule.Active,
+			"apps":   rule.Apps,
+			"hosts":  rule.Hosts,
+			"query":  rule.Query,
+		}
+		if kind.indexOnly {
+			item["indexonly"] = rule.IndexOnly
+		}
+		items = append(items, item)
+		ids = append(ids, rule.ID)
+	}
+
+	appendError(d.Set("rule", items), &diags)
+	appendError(d.Set("rule_ids", ids), &diags)
+
+	return diags
+}
+
+// delete removes every rule of the list, as the resource owns all of them
+func (kind exclusionSetKind) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	for _, r := range d.Get("rule").([]interface{}) {
+		id := r.(map[string]interface{})["id"].(string)
+		req := newRequestConfig(pc, "DELETE", fmt.Sprintf("%s/%s", kind.url, id), nil)
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s %s %s", req.method, req.apiURL, kind.name, body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func (kind exclusionSetKind) customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	seen := map[string]bool{}
+	for i, r := range d.Get("rule").([]interface{}) {
+		rule := r.(map[string]interface{})
+		title := rule["title"].(string)
+		if title == "" {
+			// Not known until apply
+			continue
+		}
+		if seen[title] {
+			return fmt.Errorf("%s rule %q is declared more than once", kind.name, title)
+		}
+		seen[title] = true
+
+		known := true
+		for _, key := range exclusionRuleAtLeastOneOfFields {
+			known = known && d.NewValueKnown(fmt.Sprintf("rule.%d.%s", i, key))
+		}
+		hasMatcher := len(rule["apps"].([]interface{})) > 0 ||
+			len(rule["hosts"].([]interface{})) > 0 ||
+			rule["query"].(string) != ""
+		if known && !hasMatcher {
+			return fmt.Errorf("%s rule %q requires one of apps, hosts or query", kind.name, title)
+		}
+	}
+
+	// The IDs of nested blocks keep their old value by position until the
+	// apply, rule_ids is the one that is known to be recomputed
+	if d.HasChange("rule") {
+		return d.SetNewComputed("rule_ids")
+	}
+	return nil
+}
+
+func (kind exclusionSetKind) ruleSchema() map[string]*schema.Schema {
+	rule := map[string]*schema.Schema{}
+	for k, v := range exclusionRuleSchema {
+		s := *v
+		// The paths of AtLeastOneOf are absolute, it is checked by the diff instead
+		s.AtLeastOneOf = nil
+		rule[k] = &s
+	}
+	// Activation windows need a plan time flag per rule, which nested blocks
+	// cannot have
+	delete(rule, "active_from")
+	delete(rule, "active_until")
+	delete(rule, "effective_active")
+	// New rules are matched by title, so it cannot be omitted
+	rule["title"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	if kind.indexOnly {
+		rule["indexonly"] = ingestionExclusionRuleSchema["indexonly"]
+	}
+	return rule
+}
+
+func (kind exclusionSetKind) resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: kind.apply,
+		UpdateContext: kind.apply,
+		ReadContext:   kind.read,
+		DeleteContext: kind.delete,
+		CustomizeDiff: kind.customizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				d.SetId(kind.id)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: kind.ruleSchema(),
+				},
+			},
+			"rule_ids": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceIngestionExclusionSet() *schema.Resource {
+	return ingestionExclusionSet.resource()
+}
+
+func resourceStreamExclusionSet() *schema.Resource {
+	return streamExclusionSet.resource()
+}
@@ -0,0 +1,223 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testExclusionRule(id, title string, active bool, query string) ingestionExclusionRule {
+	return ingestionExclusionRule{
+		exclusionRule: exclusionRule{ID: id, Title: title, Active: active, Query: query},
+		IndexOnly:     true,
+	}
+}
+
+func TestExclusionSet_planExclusionChanges(t *testing.T) {
+	current := []ingestionExclusionRule{
+		testExclusionRule("a", "health checks", true, "path:/healthz"),
+		testExclusionRule("b", "debug", true, "level:debug"),
+		testExclusionRule("c", "added in the UI", true, "foo"),
+		testExclusionRule("d", "debug", false, "level:debug"),
+	}
+
+	t.Run("Plans creates, updates and deletes", func(t *testing.T) {
+		desired := []ingestionExclusionRule{
+			testExclusionRule("", "health checks", true, "path:/healthz"),
+			testExclusionRule("", "debug", false, "level:debug"),
+			testExclusionRule("", "bots", true, "robots.txt"),
+		}
+
+		changes := planExclusionChanges(ingestionExclusionSet, current, desired)
+		assert.Equal(t, []ingestionExclusionRule{desired[2]}, changes.create)
+		assert.Equal(t, []exclusionUpdate{{id: "b", rule: desired[1]}}, changes.update)
+		assert.Equal(t, []string{"c", "d"}, changes.remove, "Duplicated titles and undeclared rules are removed")
+	})
+
+	t.Run("Matches rules by their ID first", func(t *testing.T) {
+		desired := []ingestionExclusionRule{
+			testExclusionRule("", "bots", true, "robots.txt"),
+			testExclusionRule("d", "debug", false, "level:debug"),
+			testExclusionRule("a", "health", true, "path:/healthz"),
+		}
+
+		changes := planExclusionChanges(ingestionExclusionSet, current, desired)
+		assert.Equal(t, []ingestionExclusionRule{desired[0]}, changes.create)
+		desired[2].ID = ""
+		assert.Equal(t, []exclusionUpdate{{id: "a", rule: desired[2]}}, changes.update, "Renamed rules are updated in place")
+		assert.Equal(t, []string{"b", "c"}, changes.remove, "The duplicated title that is not in the state is removed")
+	})
+
+	t.Run("Matches rules by title when the IDs have shifted", func(t *testing.T) {
+		// A rule was inserted first, the others got the IDs of the previous positions
+		desired := []ingestionExclusionRule{
+			testExclusionRule("a", "bots", true, "robots.txt"),
+			testExclusionRule("b", "health checks", true, "path:/healthz"),
+			testExclusionRule("c", "debug", true, "level:debug"),
+		}
+
+		changes := planExclusionChanges(ingestionExclusionSet, current[:2], desired)
+		desired[0].ID = ""
+		assert.Equal(t, []ingestionExclusionRule{desired[0]}, changes.create)
+		assert.Empty(t, changes.update)
+		assert.Empty(t, changes.remove)
+	})
+
+	t.Run("Ignores equivalent queries", func(t *testing.T) {
+		desired := []ingestionExclusionRule{
+			testExclusionRule("", "health checks", true, "path:/healthz  "),
+		}
+
+		changes := planExclusionChanges(ingestionExclusionSet, current[:1], desired)
+		assert.Empty(t, changes.create)
+		assert.Empty(t, changes.update)
+		assert.Empty(t, changes.remove)
+	})
+
+	t.Run("Ignores indexonly for streams", func(t *testing.T) {
+		desired := []ingestionExclusionRule{
+			testExclusionRule("", "health checks", true, "path:/healthz"),
+		}
+		desired[0].IndexOnly = false
+
+		changes := planExclusionChanges(streamExclusionSet, current[:1], desired)
+		assert.Empty(t, changes.update)
+		assert.Equal(t, desired[0].exclusionRule, streamExclusionSet.request(desired[0]))
+	})
+}
+
+func TestExclusionSet_read(t *testing.T) {
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/stream/exclusions": []exclusionRule{
+			{ID: "c", Title: "added in the UI", Query: "foo"},
+			{ID: "b", Title: "debug", Query: "level:debug"},
+			{ID: "a", Title: "health checks", Active: true, Apps: []string{"api"}},
+		},
+	})
+	defer ts.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceStreamExclusionSet().Schema, map[string]interface{}{
+		"rule": []interface{}{
+			map[string]interface{}{"title": "health checks", "apps": []interface{}{"api"}},
+			map[string]interface{}{"title": "debug", "query": "level:debug"},
+		},
+	})
+
+	diags := streamExclusionSet.read(context.Background(), d, pc)
+	assert.Empty(t, diags)
+
+	titles := []string{}
+	for _, r := range d.Get("rule").([]interface{}) {
+		titles = append(titles, r.(map[string]interface{})["title"].(string))
+	}
+	assert.Equal(t, []string{"health checks", "debug", "added in the UI"}, titles, "Declared order is kept")
+	assert.Equal(t, "a", d.Get("rule.0.id"))
+	assert.Equal(t, []interface{}{"a", "b", "c"}, d.Get("rule_ids"), "IDs are aligned with the rules")
+}
+
+func TestExclusionSet_expectInvalidError(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testExclusionSet("ingestion", `
+					rule {
+						title = "debug"
+						query = "level:debug"
+					}
+					rule {
+						title = "debug"
+						apps = ["api"]
+					}
+				`),
+				ExpectError: regexp.MustCompile(`ingestion exclusion rule "debug" is declared more than once`),
+			},
+			{
+				Config: testExclusionSet("stream", `
+					rule {
+						title = "empty"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`stream exclusion rule "empty" requires one of apps, hosts or query`),
+			},
+			{
+				Config: testExclusionSet("stream", `
+					rule {
+						title = "invalid"
+						query = "(foo"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`missing closing parenthesis for '\(' at column 1`),
+			},
+		},
+	})
+}
+
+func TestExclusionSet_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testExclusionSet("ingestion", `
+					rule {
+						title = "health checks"
+						apps = ["nginx"]
+						query = "path:/healthz"
+						active = true
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("ingestion_exclusion_set", "all"),
+					resource.TestCheckResourceAttr("logdna_ingestion_exclusion_set.all", "rule.#", "1"),
+					resource.TestCheckResourceAttrSet("logdna_ingestion_exclusion_set.all", "rule.0.id"),
+					resource.TestCheckResourceAttr("logdna_ingestion_exclusion_set.all", "rule.0.indexonly", "true"),
+				),
+			},
+			{
+				Config: testExclusionSet("ingestion", `
+					rule {
+						title = "debug"
+						query = "level:debug"
+						active = true
+						indexonly = false
+					}
+					rule {
+						title = "health checks"
+						apps = ["nginx"]
+						query = "path:/healthz"
+						active = false
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logdna_ingestion_exclusion_set.all", "rule.#", "2"),
+					resource.TestCheckResourceAttr("logdna_ingestion_exclusion_set.all", "rule.0.title", "debug"),
+					resource.TestCheckResourceAttr("logdna_ingestion_exclusion_set.all", "rule.1.active", "false"),
+					resource.TestCheckResourceAttr("logdna_ingestion_exclusion_set.all", "rule_ids.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "logdna_ingestion_exclusion_set.all",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rule"},
+			},
+		},
+	})
+}
+
+func testExclusionSet(kind string, fields string) string {
+	return fmt.Sprintf(`
+		provider "logdna" {
+			servicekey = "%s"
+			url = "%s"
+		}
+		resource "logdna_%s_exclusion_set" "all" {
+			%s
+		}
+	`, serviceKey, apiHostUrl, kind, fields)
+}
@@ -0,0 +1,94 @@
+package logdna
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGroup_ErrorsResourceFields(t *testing.T) {
+	args := map[string]string{
+		"name":    `"test"`,
+		"members": `["Test User <user@example.org>"]`,
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmtTestConfigResource("group", "new", globalPcArgs, map[string]string{}, nilOpt, nilLst),
+				ExpectError: regexp.MustCompile("The argument \"name\" is required, but no definition was found."),
+			},
+			{
+				Config:      fmtTestConfigResource("group", "new", globalPcArgs, args, nilOpt, nilLst),
+				ExpectError: regexp.MustCompile("must be a bare email address"),
+			},
+		},
+	})
+}
+
+func TestGroup_Basic(t *testing.T) {
+	viewCfg := fmtResourceBlock("view", "scoped", rsDefaults["view"], nilOpt, nilLst)
+
+	iniArgs := map[string]string{
+		"name":    `"test"`,
+		"members": `["member@example.org"]`,
+	}
+	updArgs := map[string]string{
+		"name":    `"test2"`,
+		"members": `["member@example.org", "Admin@Example.org"]`,
+	}
+	accessArgs := map[string]map[string]string{
+		"access": {
+			"apps":  `["app1", "app2"]`,
+			"hosts": `["host1"]`,
+			"views": `[logdna_view.scoped.id]`,
+		},
+	}
+
+	memberArgs := map[string]string{
+		"email":  `"member@example.org"`,
+		"role":   `"member"`,
+		"groups": `[logdna_group.new.id]`,
+	}
+	memberCfg := fmtResourceBlock("member", "member", memberArgs, nilOpt, nilLst)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmtTestConfigResource("group", "new", globalPcArgs, iniArgs, nilOpt, nilLst),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("group", "new"),
+					resource.TestCheckResourceAttr("logdna_group.new", "name", "test"),
+					resource.TestCheckResourceAttr("logdna_group.new", "members.#", "1"),
+					resource.TestCheckResourceAttr("logdna_group.new", "access.#", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(
+					"%s\n%s\n%s",
+					fmtTestConfigResource("group", "new", globalPcArgs, updArgs, accessArgs, nilLst),
+					viewCfg,
+					memberCfg,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("group", "new"),
+					resource.TestCheckResourceAttr("logdna_group.new", "name", "test2"),
+					resource.TestCheckResourceAttr("logdna_group.new", "members.#", "2"),
+					resource.TestCheckResourceAttr("logdna_group.new", "access.0.apps.#", "2"),
+					resource.TestCheckResourceAttr("logdna_group.new", "access.0.hosts.0", "host1"),
+					resource.TestCheckTypeSetElemAttrPair("logdna_group.new", "access.0.views.*", "logdna_view.scoped", "id"),
+					resource.TestCheckTypeSetElemAttrPair("logdna_member.member", "groups.*", "logdna_group.new", "id"),
+				),
+			},
+			{
+				ResourceName:      "logdna_group.new",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -102,6 +102,7 @@ func resourceIndexRateAlertRead(ctx context.Context, d *schema.ResourceData, m i
 	integrations["pagerduty"] = indexRateAlert.Channels.Pagerduty
 	integrations["slack"] = indexRateAlert.Channels.Slack
 	webhooks := mapIndexRateAlertWebhookToSchema(indexRateAlert)
+	splitSensitiveHeaders(d, webhooks)
 
 	appendError(d.Set("webhook_channel", webhooks), &diags)
 
@@ -202,15 +203,17 @@ func resourceIndexRateAlert() *schema.Resource {
 							},
 						},
 						"pagerduty": {
-							Type:     schema.TypeList,
-							Optional: true,
+							Type:      schema.TypeList,
+							Optional:  true,
+							Sensitive: true,
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
 						},
 						"slack": {
-							Type:     schema.TypeList,
-							Optional: true,
+							Type:      schema.TypeList,
+							Optional:  true,
+							Sensitive: true,
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
@@ -239,6 +242,15 @@ func resourceIndexRateAlert() *schema.Resource {
 						Type: schema.TypeString,
 					  },
 					  Computed: true,
+					  Sensitive: true,
+					},
+					"sensitive_headers": {
+					  Type: schema.TypeMap,
+					  Optional:true,
+					  Elem: &schema.Schema{
+						Type: schema.TypeString,
+					  },
+					  Sensitive: true,
 					},
 					"bodytemplate": {
 					  Type:     schema.TypeString,
@@ -16,14 +16,8 @@ func resourceIngestionExclusionCreate(ctx context.Context, d *schema.ResourceDat
 
 	pc := m.(*providerConfig)
 	ex := ingestionExclusionRule{
-		exclusionRule: exclusionRule{
-			Title:  d.Get("title").(string),
-			Active: d.Get("active").(bool),
-			Apps:   listToStrings(d.Get("apps").([]interface{})),
-			Hosts:  listToStrings(d.Get("hosts").([]interface{})),
-			Query:  d.Get("query").(string),
-		},
-		IndexOnly: d.Get("indexonly").(bool),
+		exclusionRule: exclusionRuleFromSchema(d),
+		IndexOnly:     d.Get("indexonly").(bool),
 	}
 
 	req := newRequestConfig(
@@ -45,11 +39,7 @@ func resourceIngestionExclusionCreate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	d.SetId(exn.ID)
-	appendError(d.Set("title", exn.Title), &diags)
-	appendError(d.Set("active", exn.Active), &diags)
-	appendError(d.Set("apps", exn.Apps), &diags)
-	appendError(d.Set("hosts", exn.Hosts), &diags)
-	appendError(d.Set("query", exn.Query), &diags)
+	setExclusionRule(d, exn.exclusionRule, &diags)
 
 	return diags
 }
@@ -86,12 +76,8 @@ func resourceIngestionExclusionRead(ctx context.Context, d *schema.ResourceData,
 		return diags
 	}
 
-	appendError(d.Set("title", ex.Title), &diags)
-	appendError(d.Set("active", ex.Active), &diags)
+	setExclusionRule(d, ex.exclusionRule, &diags)
 	appendError(d.Set("indexonly", ex.IndexOnly), &diags)
-	appendError(d.Set("apps", ex.Apps), &diags)
-	appendError(d.Set("hosts", ex.Hosts), &diags)
-	appendError(d.Set("query", ex.Query), &diags)
 
 	return diags
 }
@@ -99,14 +85,8 @@ func resourceIngestionExclusionRead(ctx context.Context, d *schema.ResourceData,
 func resourceIngestionExclusionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
 	ex := ingestionExclusionRule{
-		exclusionRule: exclusionRule{
-			Title:  d.Get("title").(string),
-			Active: d.Get("active").(bool),
-			Apps:   listToStrings(d.Get("apps").([]interface{})),
-			Hosts:  listToStrings(d.Get("hosts").([]interface{})),
-			Query:  d.Get("query").(string),
-		},
-		IndexOnly: d.Get("indexonly").(bool),
+		exclusionRule: exclusionRuleFromSchema(d),
+		IndexOnly:     d.Get("indexonly").(bool),
 	}
 
 	req := newRequestConfig(
@@ -148,6 +128,7 @@ func resourceIngestionExclusion() *schema.Resource {
 		ReadContext:   resourceIngestionExclusionRead,
 		UpdateContext: resourceIngestionExclusionUpdate,
 		DeleteContext: resourceIngestionExclusionDelete,
+		CustomizeDiff: customizeExclusionDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
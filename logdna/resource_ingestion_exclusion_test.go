@@ -40,6 +40,21 @@ func TestIngestionExclusion_expectInvalidError(t *testing.T) {
 				`, apiHostUrl),
 				ExpectError: regexp.MustCompile("requires 1 item minimum, but config has only 0 declared"),
 			},
+			{
+				Config: testIngestionExclusion(`
+					title = "test-title"
+					query = "(query-foo AND query-bar"
+				`, apiHostUrl),
+				ExpectError: regexp.MustCompile(`missing closing parenthesis for '\(' at column 1`),
+			},
+			{
+				Config: testIngestionExclusion(`
+					title = "test-title"
+					query = "foo"
+					active_until = "tomorrow"
+				`, apiHostUrl),
+				ExpectError: regexp.MustCompile(`expected "active_until" to be a valid RFC3339 date`),
+			},
 		},
 	})
 }
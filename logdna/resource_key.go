@@ -5,23 +5,62 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-func resourceKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	pc := m.(*providerConfig)
+var keyDurationExp = regexp.MustCompile(`^([0-9]+)([mhdw])$`)
 
-	keyType := d.Get("type").(string)
-	key := keyRequest{}
+var keyDurationUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
 
-	if diags = key.CreateRequestBody(d); diags.HasError() {
-		return diags
+// Overridden by tests to control when keys are due for rotation
+var keyNow = time.Now
+
+// parseKeyDuration parses durations such as 90m, 12h, 30d or 2w
+func parseKeyDuration(value string) (time.Duration, error) {
+	match := keyDurationExp.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("%q must be a duration such as 90m, 12h, 30d or 2w", value)
+	}
+	count, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(count) * keyDurationUnits[match[2]], nil
+}
+
+// keyRotationDue tells if a key created at the given time (Unix milliseconds)
+// has outlived the rotation period
+func keyRotationDue(created int, period string, now time.Time) bool {
+	if period == "" || created == 0 {
+		return false
+	}
+	duration, err := parseKeyDuration(period)
+	if err != nil {
+		return false
 	}
+	return !now.Before(time.UnixMilli(int64(created)).Add(duration))
+}
+
+// previousKeyExpired tells if the overlap window of the previous key has passed
+func previousKeyExpired(d interface{ Get(string) interface{} }, now time.Time) bool {
+	if d.Get("previous_id").(string) == "" {
+		return false
+	}
+	return !now.Before(time.UnixMilli(int64(d.Get("previous_expires").(int))))
+}
 
+func createKey(pc *providerConfig, keyType string, key keyRequest) (*keyResponse, error) {
 	req := newRequestConfig(
 		pc,
 		"POST",
@@ -33,48 +72,185 @@ func resourceKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{
 	log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, err
 	}
 
-	createdKey := keyResponse{}
-	err = json.Unmarshal(body, &createdKey)
+	createdKey := &keyResponse{}
+	err = json.Unmarshal(body, createdKey)
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, err
 	}
 	log.Printf("[DEBUG] After %s key, the created key is %+v", req.method, createdKey)
 
-	d.SetId(createdKey.KeyID)
+	return createdKey, nil
+}
 
-	return resourceKeyRead(ctx, d, m)
+func deleteKey(pc *providerConfig, keyID string) error {
+	req := newRequestConfig(
+		pc,
+		"DELETE",
+		fmt.Sprintf("/v1/config/keys/%s", keyID),
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s key %s", req.method, req.apiURL, body)
+
+	return err
 }
 
-func resourceKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// guardProviderKey refuses to delete the service key the provider itself
+// authenticates with, unless allow_self_delete is set
+func guardProviderKey(pc *providerConfig, d interface{ Get(string) interface{} }, keyType string, keyID string, value string) error {
+	if d.Get("allow_self_delete").(bool) || keyType != "service" || value == "" || value != pc.serviceKey {
+		return nil
+	}
+	return fmt.Errorf(
+		"refusing to delete service key %s because the provider authenticates with it; "+
+			"switch the provider to another key first, or set allow_self_delete = true",
+		keyID,
+	)
+}
+
+// keyState returns the value of a key field before the planned changes
+func keyState(d *schema.ResourceData, key string) string {
+	value, _ := d.GetChange(key)
+	return value.(string)
+}
+
+func clearPreviousKey(d *schema.ResourceData, diags *diag.Diagnostics) {
+	appendError(d.Set("previous_id", ""), diags)
+	appendError(d.Set("previous_key", ""), diags)
+	appendError(d.Set("previous_expires", 0), diags)
+}
+
+func resourceKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	pc := m.(*providerConfig)
-	keyID := d.Id()
 
 	key := keyRequest{}
+
 	if diags = key.CreateRequestBody(d); diags.HasError() {
 		return diags
 	}
 
-	req := newRequestConfig(
-		pc,
-		"PUT",
-		fmt.Sprintf("/v1/config/keys/%s", keyID),
-		key,
-	)
+	createdKey, err := createKey(pc, d.Get("type").(string), key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	body, err := req.MakeRequest()
-	log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+	d.SetId(createdKey.KeyID)
 
+	return resourceKeyRead(ctx, d, m)
+}
+
+// rotateKey creates a new key to replace the current one. The current key
+// becomes the previous key until the overlap window passes; a previous key
+// that is still around at this point is deleted right away.
+func rotateKey(d *schema.ResourceData, pc *providerConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	overlap, err := parseKeyDuration(d.Get("rotation_overlap").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[DEBUG] %s %s SUCCESS. Remote resource updated.", req.method, req.apiURL)
+	// The plan leaves the key fields unknown, their state holds the keys
+	// being replaced
+	oldID, oldKey := d.Id(), keyState(d, "key")
+	previousID, previousKey := keyState(d, "previous_id"), keyState(d, "previous_key")
 
-	return resourceKeyRead(ctx, d, m)
+	keyType := d.Get("type").(string)
+	if err := guardProviderKey(pc, d, keyType, previousID, previousKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if overlap == 0 {
+		if err := guardProviderKey(pc, d, keyType, oldID, oldKey); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	key := keyRequest{}
+	if diags = key.CreateRequestBody(d); diags.HasError() {
+		return diags
+	}
+
+	createdKey, err := createKey(pc, keyType, key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if previousID != "" {
+		if err := deleteKey(pc, previousID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(createdKey.KeyID)
+
+	if overlap == 0 {
+		if err := deleteKey(pc, oldID); err != nil {
+			return diag.FromErr(err)
+		}
+		clearPreviousKey(d, &diags)
+		return diags
+	}
+
+	appendError(d.Set("previous_id", oldID), &diags)
+	appendError(d.Set("previous_key", oldKey), &diags)
+	appendError(d.Set("previous_expires", int(keyNow().Add(overlap).UnixMilli())), &diags)
+	return diags
+}
+
+func resourceKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+
+	// customizeKeyDiff decided what happens to the keys: a planned rotation
+	// leaves the key unknown, a planned expiry clears the previous key
+	previousID := keyState(d, "previous_id")
+
+	if d.Get("key").(string) == "" {
+		// The new key is created with the current name
+		if diags = rotateKey(d, pc); diags.HasError() {
+			return diags
+		}
+	} else if previousID != "" && d.Get("previous_id").(string) == "" {
+		if err := guardProviderKey(pc, d, d.Get("type").(string), previousID, keyState(d, "previous_key")); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := deleteKey(pc, previousID); err != nil {
+			return diag.FromErr(err)
+		}
+		clearPreviousKey(d, &diags)
+	}
+
+	if d.HasChange("name") {
+		keyID := d.Id()
+
+		key := keyRequest{}
+		if diags = key.CreateRequestBody(d); diags.HasError() {
+			return diags
+		}
+
+		req := newRequestConfig(
+			pc,
+			"PUT",
+			fmt.Sprintf("/v1/config/keys/%s", keyID),
+			key,
+		)
+
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Printf("[DEBUG] %s %s SUCCESS. Remote resource updated.", req.method, req.apiURL)
+	}
+
+	return append(diags, resourceKeyRead(ctx, d, m)...)
 }
 
 func resourceKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -126,19 +302,23 @@ func resourceKeyRead(ctx context.Context, d *schema.ResourceData, m interface{})
 
 func resourceKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
-	keyID := d.Id()
+	keyType := d.Get("type").(string)
 
-	req := newRequestConfig(
-		pc,
-		"DELETE",
-		fmt.Sprintf("/v1/config/keys/%s", keyID),
-		nil,
-	)
+	// Check both keys before deleting anything
+	if err := guardProviderKey(pc, d, keyType, d.Id(), d.Get("key").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := guardProviderKey(pc, d, keyType, d.Get("previous_id").(string), d.Get("previous_key").(string)); err != nil {
+		return diag.FromErr(err)
+	}
 
-	body, err := req.MakeRequest()
-	log.Printf("[DEBUG] %s %s key %s", req.method, req.apiURL, body)
+	if previousID := d.Get("previous_id").(string); previousID != "" {
+		if err := deleteKey(pc, previousID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	if err != nil {
+	if err := deleteKey(pc, d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -146,14 +326,82 @@ func resourceKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{
 	return nil
 }
 
+// customizeKeyDiff plans the rotation of the key, and the removal of the
+// previous key once its overlap window has passed. Plans that would delete
+// the key the provider authenticates with are rejected early.
+func customizeKeyDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	pc := m.(*providerConfig)
+	now := keyNow()
+
+	if d.HasChange("type") {
+		oldType, _ := d.GetChange("type")
+		return guardProviderKey(pc, d, oldType.(string), d.Id(), d.Get("key").(string))
+	}
+
+	if d.HasChange("rotate_trigger") || keyRotationDue(d.Get("created").(int), d.Get("rotation_period").(string), now) {
+		log.Printf("[DEBUG] Key %s is due for rotation", d.Id())
+		keyType := d.Get("type").(string)
+		if err := guardProviderKey(pc, d, keyType, d.Get("previous_id").(string), d.Get("previous_key").(string)); err != nil {
+			return err
+		}
+		if overlap, _ := parseKeyDuration(d.Get("rotation_overlap").(string)); overlap == 0 {
+			if err := guardProviderKey(pc, d, keyType, d.Id(), d.Get("key").(string)); err != nil {
+				return err
+			}
+		}
+		for _, key := range []string{"id", "key", "created", "previous_id", "previous_key", "previous_expires"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if previousKeyExpired(d, now) {
+		log.Printf("[DEBUG] The overlap window of key %s has passed", d.Get("previous_id"))
+		if err := guardProviderKey(pc, d, d.Get("type").(string), d.Get("previous_id").(string), d.Get("previous_key").(string)); err != nil {
+			return err
+		}
+		for key, value := range map[string]interface{}{"previous_id": "", "previous_key": "", "previous_expires": 0} {
+			if err := d.SetNew(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importKeyState sets the default overlap window, which cannot be read back
+// from the API. Imported keys start without a previous key.
+func importKeyState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("rotation_overlap", "24h"); err != nil {
+		return nil, err
+	}
+	if err := d.Set("allow_self_delete", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func validateKeyDuration(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseKeyDuration(val.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q: %s", key, err))
+	}
+	return
+}
+
 func resourceKey() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKeyCreate,
 		UpdateContext: resourceKeyUpdate,
 		ReadContext:   resourceKeyRead,
 		DeleteContext: resourceKeyDelete,
+		CustomizeDiff: customizeKeyDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importKeyState,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -170,20 +418,53 @@ func resourceKey() *schema.Resource {
 					return new == ""
 				},
 			},
+			"rotation_period": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.All(
+					validateKeyDuration,
+					validation.StringDoesNotMatch(regexp.MustCompile(`^0+[mhdw]$`), "must be longer than zero"),
+				),
+			},
+			"rotate_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rotation_overlap": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "24h",
+				ValidateFunc: validateKeyDuration,
+			},
+			"allow_self_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"id": {
 				Type:     schema.TypeString,
-				ForceNew: true,
 				Computed: true,
 			},
 			"key": {
 				Type:      schema.TypeString,
-				ForceNew:  true,
 				Sensitive: true,
 				Computed:  true,
 			},
 			"created": {
 				Type:     schema.TypeInt,
-				ForceNew: true,
+				Computed: true,
+			},
+			"previous_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"previous_key": {
+				Type:      schema.TypeString,
+				Sensitive: true,
+				Computed:  true,
+			},
+			"previous_expires": {
+				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
@@ -1,13 +1,228 @@
 package logdna
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
+// fakeKeyAPI is an in-memory implementation of the /v1/config/keys API
+type fakeKeyAPI struct {
+	sync.Mutex
+	keys    map[string]keyResponse
+	counter int
+	now     time.Time
+}
+
+func newFakeKeyAPI(t *testing.T, now time.Time) (*fakeKeyAPI, *httptest.Server) {
+	api := &fakeKeyAPI{keys: map[string]keyResponse{}, now: now}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.Lock()
+		defer api.Unlock()
+
+		id := strings.TrimPrefix(r.URL.Path, "/v1/config/keys/")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/v1/config/keys":
+			req := keyRequest{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&req), "No errors")
+			api.counter++
+			key := keyResponse{
+				KeyID:   fmt.Sprintf("k%d", api.counter),
+				Key:     fmt.Sprintf("secret%d", api.counter),
+				Name:    req.Name,
+				Type:    r.URL.Query().Get("type"),
+				Created: int(api.now.UnixMilli()),
+			}
+			api.keys[key.KeyID] = key
+			assert.Nil(t, json.NewEncoder(w).Encode(key), "No errors")
+		case r.Method == "GET" || r.Method == "PUT":
+			key, ok := api.keys[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == "PUT" {
+				req := keyRequest{}
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&req), "No errors")
+				key.Name = req.Name
+				api.keys[id] = key
+			}
+			assert.Nil(t, json.NewEncoder(w).Encode(key), "No errors")
+		case r.Method == "DELETE":
+			if _, ok := api.keys[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(api.keys, id)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	return api, ts
+}
+
+func (api *fakeKeyAPI) ids() []string {
+	api.Lock()
+	defer api.Unlock()
+	ids := []string{}
+	for id := range api.keys {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// planKeyUpdate plans the configuration against the state of d and applies
+// that plan, like terraform does for an update
+func planKeyUpdate(t *testing.T, d *schema.ResourceData, cfg map[string]interface{}, pc *providerConfig) (*schema.ResourceData, diag.Diagnostics) {
+	state := d.State()
+	diff, err := resourceKey().Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), pc)
+	if err != nil {
+		return d, diag.FromErr(err)
+	}
+	applied, err := schema.InternalMap(resourceKey().Schema).Data(state, diff)
+	assert.Nil(t, err, "No errors")
+	return applied, resourceKeyUpdate(context.Background(), applied, pc)
+}
+
+func TestKey_parseKeyDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"0m":  0,
+		"90m": 90 * time.Minute,
+		"12h": 12 * time.Hour,
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+	} {
+		duration, err := parseKeyDuration(value)
+		assert.Nil(t, err, "No errors")
+		assert.Equal(t, expected, duration, value)
+	}
+
+	for _, value := range []string{"", "1y", "1h30m", "-1h"} {
+		_, err := parseKeyDuration(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestKey_rotation(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer func() { keyNow = time.Now }()
+
+	t.Run("Rotates keys past the rotation period and keeps the previous key during the overlap", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "abc123", baseURL: ts.URL, httpClient: ts.Client()}
+
+		cfg := map[string]interface{}{
+			"type":             "ingestion",
+			"name":             "agents",
+			"rotation_period":  "30d",
+			"rotation_overlap": "2d",
+		}
+		d := schema.TestResourceDataRaw(t, resourceKey().Schema, cfg)
+		keyNow = func() time.Time { return start }
+		assert.Empty(t, resourceKeyCreate(context.Background(), d, pc), "No errors")
+		assert.Equal(t, "k1", d.Id())
+		assert.Equal(t, "", d.Get("previous_id"))
+
+		// Not due yet
+		keyNow = func() time.Time { return start.Add(29 * 24 * time.Hour) }
+		d, diags := planKeyUpdate(t, d, cfg, pc)
+		assert.Empty(t, diags, "No errors")
+		assert.Equal(t, "k1", d.Id())
+
+		rotation := start.Add(30 * 24 * time.Hour)
+		keyNow = func() time.Time { return rotation }
+		api.now = rotation
+		d, diags = planKeyUpdate(t, d, cfg, pc)
+		assert.Empty(t, diags, "No errors")
+		assert.Equal(t, "k2", d.Id())
+		assert.Equal(t, "secret2", d.Get("key"))
+		assert.Equal(t, "agents", d.Get("name"))
+		assert.Equal(t, "k1", d.Get("previous_id"))
+		assert.Equal(t, "secret1", d.Get("previous_key"))
+		assert.Equal(t, int(rotation.Add(48*time.Hour).UnixMilli()), d.Get("previous_expires"))
+		assert.ElementsMatch(t, []string{"k1", "k2"}, api.ids())
+
+		// The previous key is deleted on the first apply after the overlap
+		keyNow = func() time.Time { return rotation.Add(49 * time.Hour) }
+		d, diags = planKeyUpdate(t, d, cfg, pc)
+		assert.Empty(t, diags, "No errors")
+		assert.Equal(t, "k2", d.Id())
+		assert.Equal(t, "", d.Get("previous_id"))
+		assert.Equal(t, "", d.Get("previous_key"))
+		assert.Equal(t, []string{"k2"}, api.ids())
+
+		assert.Empty(t, resourceKeyDelete(context.Background(), d, pc), "No errors")
+		assert.Empty(t, api.ids())
+	})
+
+	t.Run("Applies what was planned even when the clock has moved on", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "abc123", baseURL: ts.URL, httpClient: ts.Client()}
+
+		cfg := map[string]interface{}{
+			"type":             "ingestion",
+			"name":             "agents",
+			"rotation_period":  "30d",
+			"rotation_overlap": "2d",
+		}
+		d := schema.TestResourceDataRaw(t, resourceKey().Schema, cfg)
+		keyNow = func() time.Time { return start }
+		assert.Empty(t, resourceKeyCreate(context.Background(), d, pc), "No errors")
+
+		// The plan is made before the rotation is due, the apply after
+		keyNow = func() time.Time { return start.Add(29 * 24 * time.Hour) }
+		state := d.State()
+		cfg["name"] = "renamed"
+		diff, err := resourceKey().Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), pc)
+		assert.Nil(t, err, "No errors")
+		keyNow = func() time.Time { return start.Add(31 * 24 * time.Hour) }
+		d, err = schema.InternalMap(resourceKey().Schema).Data(state, diff)
+		assert.Nil(t, err, "No errors")
+		assert.Empty(t, resourceKeyUpdate(context.Background(), d, pc), "No errors")
+		assert.Equal(t, "k1", d.Id(), "No rotation was planned")
+		assert.Equal(t, "renamed", d.Get("name"))
+		assert.Equal(t, []string{"k1"}, api.ids())
+	})
+
+	t.Run("Rotates keys when the trigger changes", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "abc123", baseURL: ts.URL, httpClient: ts.Client()}
+		keyNow = func() time.Time { return start }
+
+		cfg := map[string]interface{}{
+			"type":             "service",
+			"rotate_trigger":   "2022-01",
+			"rotation_overlap": "0m",
+		}
+		d := schema.TestResourceDataRaw(t, resourceKey().Schema, cfg)
+		assert.Empty(t, resourceKeyCreate(context.Background(), d, pc), "No errors")
+		assert.Equal(t, "k1", d.Id())
+
+		cfg["rotate_trigger"] = "2022-02"
+		d, diags := planKeyUpdate(t, d, cfg, pc)
+		assert.Empty(t, diags, "No errors")
+		assert.Equal(t, "k2", d.Id())
+		assert.Equal(t, "", d.Get("previous_id"), "No overlap was requested")
+		assert.Equal(t, []string{"k2"}, api.ids())
+	})
+}
+
 func TestKey_ErrorResourceTypeUndefined(t *testing.T) {
 	args := map[string]string{}
 
@@ -123,3 +338,86 @@ func TestKey_Basic(t *testing.T) {
 		},
 	})
 }
+
+func TestKey_guardProviderKey(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	keyNow = func() time.Time { return start }
+	defer func() { keyNow = time.Now }()
+
+	newKey := func(t *testing.T, pc *providerConfig, args map[string]interface{}) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceKey().Schema, args)
+		assert.Empty(t, resourceKeyCreate(context.Background(), d, pc), "No errors")
+		// The provider authenticates with the key that was just created
+		pc.serviceKey = d.Get("key").(string)
+		return d
+	}
+
+	t.Run("Refuses to delete the key the provider authenticates with", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "bootstrap", baseURL: ts.URL, httpClient: ts.Client()}
+
+		d := newKey(t, pc, map[string]interface{}{"type": "service"})
+		diags := resourceKeyDelete(context.Background(), d, pc)
+		assert.True(t, diags.HasError(), "The message is of type `Error`")
+		assert.Contains(t, diags[0].Summary, "refusing to delete service key k1 because the provider authenticates with it")
+		assert.Equal(t, []string{"k1"}, api.ids())
+	})
+
+	t.Run("Deletes the key the provider authenticates with when allowed", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "bootstrap", baseURL: ts.URL, httpClient: ts.Client()}
+
+		d := newKey(t, pc, map[string]interface{}{"type": "service", "allow_self_delete": true})
+		assert.Empty(t, resourceKeyDelete(context.Background(), d, pc), "No errors")
+		assert.Empty(t, api.ids())
+	})
+
+	t.Run("Deletes other keys", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "bootstrap", baseURL: ts.URL, httpClient: ts.Client()}
+
+		d := schema.TestResourceDataRaw(t, resourceKey().Schema, map[string]interface{}{"type": "service"})
+		assert.Empty(t, resourceKeyCreate(context.Background(), d, pc), "No errors")
+		assert.Empty(t, resourceKeyDelete(context.Background(), d, pc), "No errors")
+		assert.Empty(t, api.ids())
+	})
+
+	t.Run("Refuses to rotate the key the provider authenticates with without an overlap", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "bootstrap", baseURL: ts.URL, httpClient: ts.Client()}
+
+		cfg := map[string]interface{}{"type": "service", "rotate_trigger": "1", "rotation_overlap": "0h"}
+		d := newKey(t, pc, cfg)
+		cfg["rotate_trigger"] = "2"
+		d, diags := planKeyUpdate(t, d, cfg, pc)
+		assert.True(t, diags.HasError(), "The message is of type `Error`")
+		assert.Equal(t, "k1", d.Id())
+		assert.Equal(t, []string{"k1"}, api.ids(), "No key was created")
+	})
+
+	t.Run("Keeps the previous key the provider authenticates with after the overlap", func(t *testing.T) {
+		api, ts := newFakeKeyAPI(t, start)
+		defer ts.Close()
+		pc := &providerConfig{serviceKey: "bootstrap", baseURL: ts.URL, httpClient: ts.Client()}
+
+		cfg := map[string]interface{}{"type": "service", "rotation_period": "1h", "rotation_overlap": "30m"}
+		d := newKey(t, pc, cfg)
+		keyNow = func() time.Time { return start.Add(time.Hour) }
+		api.now = start.Add(time.Hour)
+		d, diags := planKeyUpdate(t, d, cfg, pc)
+		assert.Empty(t, diags, "No errors")
+		assert.Equal(t, "k2", d.Id())
+		assert.Equal(t, "k1", d.Get("previous_id"))
+
+		// The new key is not due yet, but the overlap has passed
+		keyNow = func() time.Time { return start.Add(105 * time.Minute) }
+		_, diags = planKeyUpdate(t, d, cfg, pc)
+		assert.True(t, diags.HasError(), "The message is of type `Error`")
+		assert.Contains(t, diags[0].Summary, "service key k1")
+		assert.ElementsMatch(t, []string{"k1", "k2"}, api.ids())
+	})
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -44,40 +46,89 @@ func resourceMemberCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	d.SetId(createdMember.Email)
 
+	if d.Get("wait_for_acceptance").(bool) {
+		if err := waitForMemberAcceptance(ctx, pc, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceMemberRead(ctx, d, m)
 }
 
-func resourceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
-	pc := m.(*providerConfig)
-	memberID := d.Id()
-
+func getMember(pc *providerConfig, email string) (*memberResponse, error) {
 	req := newRequestConfig(
 		pc,
 		"GET",
-		fmt.Sprintf("/v1/config/members/%s", memberID),
+		fmt.Sprintf("/v1/config/members/%s", email),
 		nil,
 	)
 
 	body, err := req.MakeRequest()
-
 	log.Printf("[DEBUG] GET member raw response body %s\n", body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Cannot read the remote member resource",
-			Detail:   err.Error(),
-		})
-		return diags
+		return nil, err
+	}
+
+	member := &memberResponse{}
+	if err = json.Unmarshal(body, member); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal member response: %s", err)
+	}
+	return member, nil
+}
+
+// Overridden by tests to avoid waiting between polls
+var memberInvitePollInterval = 10 * time.Second
+
+// waitForMemberAcceptance polls the member until their invitation is accepted.
+// An expired invitation fails right away since it can only be resent.
+func waitForMemberAcceptance(ctx context.Context, pc *providerConfig, email string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"pending"},
+		Target:       []string{"accepted"},
+		Timeout:      timeout,
+		PollInterval: memberInvitePollInterval,
+		Refresh: func() (interface{}, string, error) {
+			member, err := getMember(pc, email)
+			if err != nil {
+				return nil, "", err
+			}
+			if member.InviteStatus == "expired" {
+				return nil, "", fmt.Errorf("the invitation sent to %s has expired, change resend_trigger to send it again", email)
+			}
+			return member, member.InviteStatus, nil
+		},
 	}
 
-	member := memberResponse{}
-	err = json.Unmarshal(body, &member)
+	log.Printf("[DEBUG] Waiting up to %s for %s to accept their invitation", timeout, email)
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("%s did not accept their invitation: %s", email, err)
+	}
+	return nil
+}
+
+func resendMemberInvitation(pc *providerConfig, email string) error {
+	req := newRequestConfig(
+		pc,
+		"POST",
+		fmt.Sprintf("/v1/config/members/%s/invite", email),
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+	return err
+}
+
+func resourceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+
+	member, err := getMember(pc, d.Id())
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Cannot unmarshal response from the remote member resource",
+			Summary:  "Cannot read the remote member resource",
 			Detail:   err.Error(),
 		})
 		return diags
@@ -88,6 +139,10 @@ func resourceMemberRead(ctx context.Context, d *schema.ResourceData, m interface
 	appendError(d.Set("email", member.Email), &diags)
 	appendError(d.Set("role", member.Role), &diags)
 	appendError(d.Set("groups", member.Groups), &diags)
+	appendError(d.Set("invite_status", member.InviteStatus), &diags)
+	appendError(d.Set("account_status", member.AccountStatus), &diags)
+	appendError(d.Set("invited_at", member.InvitedAt), &diags)
+	appendError(d.Set("accepted_at", member.AcceptedAt), &diags)
 
 	return diags
 }
@@ -118,6 +173,19 @@ func resourceMemberUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	log.Printf("[DEBUG] %s %s SUCCESS. Remote resource updated.", req.method, req.apiURL)
 
+	status := d.Get("invite_status").(string)
+	if d.HasChange("resend_trigger") && (status == "pending" || status == "expired") {
+		if err := resendMemberInvitation(pc, memberID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.Get("wait_for_acceptance").(bool) && status != "accepted" {
+		if err := waitForMemberAcceptance(ctx, pc, memberID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceMemberRead(ctx, d, m)
 }
 
@@ -143,6 +211,88 @@ func resourceMemberDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	return nil
 }
 
+// importMemberState sets the options that only exist in the configuration to
+// their defaults, since they cannot be read back from the API
+func importMemberState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("wait_for_acceptance", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// customizeMemberDiff requires groups to be referenced by ID, which is easy to
+// get wrong since groups used to be free-form names
+func customizeMemberDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("groups") || (d.Id() != "" && !d.HasChange("groups")) {
+		return nil
+	}
+	declared := setToStrings(d.Get("groups").(*schema.Set))
+	if len(declared) == 0 {
+		return nil
+	}
+
+	groups, err := listGroups(m.(*providerConfig))
+	if err != nil {
+		return fmt.Errorf("cannot list the remote group resources: %s", err)
+	}
+	ids := map[string]bool{}
+	names := map[string]string{}
+	for _, group := range groups {
+		ids[group.GroupID] = true
+		names[group.Name] = group.GroupID
+	}
+
+	for _, group := range declared {
+		if ids[group] {
+			continue
+		}
+		if id, ok := names[group]; ok {
+			return fmt.Errorf("groups must contain group IDs, use %q instead of the name %q, e.g. logdna_group.example.id", id, group)
+		}
+		return fmt.Errorf("no group with the ID %q was found", group)
+	}
+	return nil
+}
+
+// resourceMemberV0 is the schema from before groups became a set
+func resourceMemberV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"groups": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+		},
+	}
+}
+
+// resourceMemberStateUpgradeV0 drops duplicated groups, which a set cannot hold
+func resourceMemberStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	groups, ok := rawState["groups"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+	unique := []interface{}{}
+	seen := map[interface{}]bool{}
+	for _, group := range groups {
+		if !seen[group] {
+			seen[group] = true
+			unique = append(unique, group)
+		}
+	}
+	rawState["groups"] = unique
+	return rawState, nil
+}
+
 func resourceMember() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceMemberCreate,
@@ -150,7 +300,20 @@ func resourceMember() *schema.Resource {
 		ReadContext:   resourceMemberRead,
 		DeleteContext: resourceMemberDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importMemberState,
+		},
+		CustomizeDiff: customizeMemberDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceMemberV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceMemberStateUpgradeV0,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -165,13 +328,38 @@ func resourceMember() *schema.Resource {
 			"role": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringInSlice([]string{"owner", "admin", "member", "readonly"}, false),
+				ValidateFunc: validation.StringIsNotEmpty,
 			},
 			"groups": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Optional: true,
 			},
+			"wait_for_acceptance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"resend_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"invite_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"invited_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"accepted_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
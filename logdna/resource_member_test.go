@@ -1,11 +1,19 @@
 package logdna
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMember_ErrorRoleEmpty(t *testing.T) {
@@ -24,6 +32,87 @@ func TestMember_ErrorRoleEmpty(t *testing.T) {
 	})
 }
 
+func TestMember_validateRole(t *testing.T) {
+	for _, role := range append(memberRoles, "Dashboards editor", "5f3a1b2c3d4e5f6a7b8c9d0e") {
+		diags := resourceMember().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"email": "user@example.org",
+			"role":  role,
+		}))
+		assert.False(t, diags.HasError(), role)
+	}
+
+	diags := resourceMember().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"email": "user@example.org",
+		"role":  "",
+	}))
+	assert.True(t, diags.HasError(), "An empty role is rejected")
+}
+
+func TestMember_customizeMemberDiff(t *testing.T) {
+	ts, pc := mockListServer(t, map[string]interface{}{
+		"/v1/config/groups": []groupResponse{
+			{GroupID: "g1", Name: "payments"},
+		},
+	})
+	defer ts.Close()
+
+	plan := func(groups ...interface{}) error {
+		_, err := resourceMember().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"email":  "user@example.org",
+			"role":   "member",
+			"groups": groups,
+		}), pc)
+		return err
+	}
+
+	assert.Nil(t, plan("g1"), "No errors")
+	assert.EqualError(t, plan("payments"), `groups must contain group IDs, use "g1" instead of the name "payments", e.g. logdna_group.example.id`)
+	assert.EqualError(t, plan("g2"), `no group with the ID "g2" was found`)
+}
+
+func TestMember_stateUpgradeV0(t *testing.T) {
+	state, err := resourceMemberStateUpgradeV0(context.Background(), map[string]interface{}{
+		"id":     "user@example.org",
+		"email":  "user@example.org",
+		"role":   "member",
+		"groups": []interface{}{"g1", "g2", "g1"},
+	}, nil)
+	assert.Nil(t, err, "No errors")
+	assert.Equal(t, []interface{}{"g1", "g2"}, state["groups"])
+
+	state, err = resourceMemberStateUpgradeV0(context.Background(), map[string]interface{}{
+		"id":    "user@example.org",
+		"email": "user@example.org",
+		"role":  "member",
+	}, nil)
+	assert.Nil(t, err, "No errors")
+	assert.NotContains(t, state, "groups")
+}
+
+func TestMember_customRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmtProviderBlock(serviceKey, apiHostUrl) + `
+					resource "logdna_role" "editor" {
+						name        = "Dashboards editor"
+						permissions = ["boards.read", "boards.write"]
+					}
+					resource "logdna_member" "editor" {
+						email = "editor@example.org"
+						role  = logdna_role.editor.name
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("member", "editor"),
+					resource.TestCheckResourceAttrPair("logdna_member.editor", "role", "logdna_role.editor", "name"),
+				),
+			},
+		},
+	})
+}
+
 func TestMember_Basic(t *testing.T) {
 	memberArgs := map[string]string{
 		"email": `"member@example.org"`,
@@ -43,6 +132,8 @@ func TestMember_Basic(t *testing.T) {
 					testResourceExists("member", "member"),
 					resource.TestCheckResourceAttr("logdna_member.member", "email", strings.Replace(memberArgs["email"], "\"", "", 2)),
 					resource.TestCheckResourceAttr("logdna_member.member", "role", strings.Replace(memberArgs["role"], "\"", "", 2)),
+					resource.TestCheckResourceAttr("logdna_member.member", "invite_status", "pending"),
+					resource.TestCheckResourceAttrSet("logdna_member.member", "invited_at"),
 				),
 			},
 			{
@@ -66,3 +157,85 @@ func TestMember_Basic(t *testing.T) {
 		},
 	})
 }
+
+func TestMember_waitForMemberAcceptance(t *testing.T) {
+	pollInterval := memberInvitePollInterval
+	memberInvitePollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { memberInvitePollInterval = pollInterval })
+
+	statuses := map[string][]string{
+		"late@example.org":    {"pending", "pending", "accepted"},
+		"expired@example.org": {"pending", "expired"},
+		"never@example.org":   {"pending"},
+	}
+	calls := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email := strings.TrimPrefix(r.URL.Path, "/v1/config/members/")
+		list := statuses[email]
+		status := list[len(list)-1]
+		if calls[email] < len(list) {
+			status = list[calls[email]]
+		}
+		calls[email]++
+		assert.Nil(t, json.NewEncoder(w).Encode(memberResponse{Email: email, InviteStatus: status}), "No errors")
+	}))
+	defer ts.Close()
+	pc := &providerConfig{serviceKey: "abc123", baseURL: ts.URL, httpClient: ts.Client()}
+
+	t.Run("Waits until the invitation is accepted", func(t *testing.T) {
+		err := waitForMemberAcceptance(context.Background(), pc, "late@example.org", time.Minute)
+		assert.Nil(t, err, "No errors")
+		assert.Equal(t, 3, calls["late@example.org"])
+	})
+
+	t.Run("Fails when the invitation expires", func(t *testing.T) {
+		err := waitForMemberAcceptance(context.Background(), pc, "expired@example.org", time.Minute)
+		assert.Regexp(t, "the invitation sent to expired@example.org has expired", err)
+	})
+
+	t.Run("Fails when the timeout is reached", func(t *testing.T) {
+		err := waitForMemberAcceptance(context.Background(), pc, "never@example.org", 100*time.Millisecond)
+		assert.Regexp(t, "never@example.org did not accept their invitation", err)
+	})
+}
+
+func TestMember_resendTrigger(t *testing.T) {
+	invites := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/invite") {
+			invites++
+		}
+		assert.Nil(t, json.NewEncoder(w).Encode(memberResponse{
+			Email:        "user@example.org",
+			Role:         "member",
+			InviteStatus: "pending",
+		}), "No errors")
+	}))
+	defer ts.Close()
+	pc := &providerConfig{serviceKey: "abc123", baseURL: ts.URL, httpClient: ts.Client()}
+
+	state := &terraform.InstanceState{
+		ID: "user@example.org",
+		Attributes: map[string]string{
+			"id":             "user@example.org",
+			"email":          "user@example.org",
+			"role":           "member",
+			"invite_status":  "pending",
+			"resend_trigger": "1",
+		},
+		Meta: map[string]interface{}{"schema_version": "1"},
+	}
+	apply := func(cfg map[string]interface{}) {
+		diff, err := resourceMember().Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), pc)
+		assert.Nil(t, err, "No errors")
+		d, err := schema.InternalMap(resourceMember().Schema).Data(state, diff)
+		assert.Nil(t, err, "No errors")
+		assert.False(t, resourceMemberUpdate(context.Background(), d, pc).HasError(), "No errors")
+	}
+
+	apply(map[string]interface{}{"email": "user@example.org", "role": "admin", "resend_trigger": "1"})
+	assert.Equal(t, 0, invites, "The trigger did not change")
+
+	apply(map[string]interface{}{"email": "user@example.org", "role": "member", "resend_trigger": "2"})
+	assert.Equal(t, 1, invites, "The trigger changed")
+}
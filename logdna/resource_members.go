@@ -0,0 +1,379 @@
+package logdna
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The organization has a single member list, so the resource has a fixed ID
+const membersResourceID = "members"
+
+// memberChanges lists the requests needed to turn the remote member list into
+// the declared one
+type memberChanges struct {
+	invite []memberRequest
+	update map[string]memberPutRequest
+	remove []string
+}
+
+// planMemberChanges compares the remote members with the declared ones.
+// Allowlisted emails are never touched. It refuses to remove the last owner,
+// and the member the provider credentials belong to.
+func planMemberChanges(current []memberResponse, desired []memberRequest, allowlist []string, self string) (*memberChanges, error) {
+	changes := &memberChanges{update: map[string]memberPutRequest{}}
+
+	remote := map[string]memberResponse{}
+	for _, member := range current {
+		remote[strings.ToLower(member.Email)] = member
+	}
+	allowed := map[string]bool{}
+	for _, email := range allowlist {
+		allowed[strings.ToLower(email)] = true
+	}
+
+	declared := map[string]bool{}
+	// Only members who already are owners, and stay so, count: invitations
+	// may never be accepted
+	owners := 0
+	for _, member := range desired {
+		key := strings.ToLower(member.Email)
+		declared[key] = true
+
+		existing, ok := remote[key]
+		if !ok {
+			changes.invite = append(changes.invite, member)
+			continue
+		}
+		if existing.Role == "owner" && member.Role == "owner" {
+			owners++
+		}
+		if existing.Role != member.Role || !sameStrings(existing.Groups, member.Groups) {
+			changes.update[existing.Email] = memberPutRequest{Role: member.Role, Groups: member.Groups}
+		}
+	}
+
+	hadOwner := false
+	for key, member := range remote {
+		if member.Role == "owner" {
+			hadOwner = true
+		}
+		if declared[key] {
+			continue
+		}
+		if allowed[key] {
+			if member.Role == "owner" {
+				owners++
+			}
+			continue
+		}
+		if self != "" && strings.EqualFold(member.Email, self) {
+			return nil, fmt.Errorf(
+				"refusing to remove %s, the member whose credentials the provider is using; declare it or add it to the allowlist",
+				member.Email,
+			)
+		}
+		changes.remove = append(changes.remove, member.Email)
+	}
+	sort.Strings(changes.remove)
+
+	if hadOwner && owners == 0 {
+		return nil, fmt.Errorf("refusing to remove the last owner of the organization; declare at least one existing owner or add one to the allowlist")
+	}
+
+	return changes, nil
+}
+
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := map[string]int{}
+	for _, v := range a {
+		seen[v]++
+	}
+	for _, v := range b {
+		if seen[v] == 0 {
+			return false
+		}
+		seen[v]--
+	}
+	return true
+}
+
+// iamTokenEmail returns the email claim of an IAM token, which identifies the
+// member the provider is acting as
+func iamTokenEmail(token string) string {
+	parts := strings.Split(strings.TrimPrefix(token, "Bearer "), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+	claims := struct {
+		Email string `json:"email"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Email
+}
+
+// providerMemberEmail returns the member the provider credentials belong to.
+// Requests are authenticated with the service key whenever one is set, and
+// service keys cannot be traced back to a member, so it returns "" in that
+// case.
+func providerMemberEmail(pc *providerConfig) string {
+	if pc.serviceKey != "" {
+		return ""
+	}
+	return iamTokenEmail(pc.iamtoken)
+}
+
+// planMembers lists the remote members and plans the changes declared in d.
+// Removals are refused when the member the provider credentials belong to is
+// unknown, since nothing would prevent its removal.
+func planMembers(d interface{ Get(string) interface{} }, pc *providerConfig) (*memberChanges, error) {
+	current, err := listMembers(pc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the remote member resources: %s", err)
+	}
+
+	self := d.Get("protected_member").(string)
+	if self == "" {
+		self = providerMemberEmail(pc)
+	}
+	changes, err := planMemberChanges(
+		current,
+		membersFromSchema(d),
+		setToStrings(d.Get("allowlist").(*schema.Set)),
+		self,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if self == "" && len(changes.remove) > 0 {
+		return nil, fmt.Errorf(
+			"refusing to remove %s, the member the provider credentials belong to is unknown; set protected_member to the email of the member who owns them",
+			strings.Join(changes.remove, ", "),
+		)
+	}
+	return changes, nil
+}
+
+func membersFromSchema(d interface{ Get(string) interface{} }) []memberRequest {
+	members := []memberRequest{}
+	for _, m := range d.Get("member").(*schema.Set).List() {
+		member := m.(map[string]interface{})
+		members = append(members, memberRequest{
+			Email:  member["email"].(string),
+			Role:   member["role"].(string),
+			Groups: setToStrings(member["groups"].(*schema.Set)),
+		})
+	}
+	return members
+}
+
+func listMembers(pc *providerConfig) ([]memberResponse, error) {
+	members := []memberResponse{}
+	if err := listRemote(pc, "/v1/config/members", &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func resourceMembersApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	// The changes are planned again, members may have changed since the plan
+	changes, err := planMembers(d, pc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[DEBUG] Planned member changes: %+v", changes)
+
+	for _, member := range changes.invite {
+		req := newRequestConfig(pc, "POST", "/v1/config/members", member)
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for email, member := range changes.update {
+		req := newRequestConfig(pc, "PUT", fmt.Sprintf("/v1/config/members/%s", email), member)
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, email := range changes.remove {
+		req := newRequestConfig(pc, "DELETE", fmt.Sprintf("/v1/config/members/%s", email), nil)
+		body, err := req.MakeRequest()
+		log.Printf("[DEBUG] %s %s member %s", req.method, req.apiURL, body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(membersResourceID)
+
+	return resourceMembersRead(ctx, d, m)
+}
+
+func resourceMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+
+	members, err := listMembers(pc)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot read the remote member resources",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	log.Printf("[DEBUG] The GET members structure is as follows: %+v\n", members)
+
+	allowed := map[string]bool{}
+	for _, email := range setToStrings(d.Get("allowlist").(*schema.Set)) {
+		allowed[strings.ToLower(email)] = true
+	}
+	// Keep the casing used in the configuration to avoid spurious diffs
+	known := map[string]string{}
+	for _, member := range membersFromSchema(d) {
+		known[strings.ToLower(member.Email)] = member.Email
+	}
+
+	items := []interface{}{}
+	for _, member := range members {
+		key := strings.ToLower(member.Email)
+		if allowed[key] {
+			continue
+		}
+		email := member.Email
+		if configured, ok := known[key]; ok {
+			email = configured
+		}
+		items = append(items, map[string]interface{}{
+			"email":  email,
+			"role":   member.Role,
+			"groups": member.Groups,
+		})
+	}
+
+	appendError(d.Set("member", items), &diags)
+
+	return diags
+}
+
+// resourceMembersDelete only forgets the member list: destroying the resource
+// must not lock everybody out of the organization
+func resourceMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing the member list from the state, remote members are left untouched")
+	d.SetId("")
+	return nil
+}
+
+func customizeMembersDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	allowed := map[string]bool{}
+	for _, email := range setToStrings(d.Get("allowlist").(*schema.Set)) {
+		allowed[strings.ToLower(email)] = true
+	}
+
+	seen := map[string]bool{}
+	for _, m := range d.Get("member").(*schema.Set).List() {
+		email := m.(map[string]interface{})["email"].(string)
+		key := strings.ToLower(email)
+		if email == "" {
+			// Not known until apply
+			continue
+		}
+		if seen[key] {
+			return fmt.Errorf("member %s is declared more than once", email)
+		}
+		if allowed[key] {
+			return fmt.Errorf("member %s cannot be both declared and allowlisted", email)
+		}
+		seen[key] = true
+	}
+
+	if !d.NewValueKnown("member") || !d.NewValueKnown("allowlist") || !d.NewValueKnown("protected_member") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("member", "allowlist", "protected_member") {
+		return nil
+	}
+	// Refusals are reported in the plan rather than during the apply
+	_, err := planMembers(d, m.(*providerConfig))
+	return err
+}
+
+func resourceMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMembersApply,
+		UpdateContext: resourceMembersApply,
+		ReadContext:   resourceMembersRead,
+		DeleteContext: resourceMembersDelete,
+		CustomizeDiff: customizeMembersDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				d.SetId(membersResourceID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"member": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateEmailAddress,
+						},
+						"role": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"groups": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Optional: true,
+						},
+					},
+				},
+			},
+			"allowlist": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateEmailAddress,
+				},
+				Optional: true,
+			},
+			"protected_member": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateEmailAddress,
+			},
+		},
+	}
+}
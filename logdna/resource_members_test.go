@@ -0,0 +1,236 @@
+package logdna
+
+import (
+	"context"
+	"encoding/base64"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembers_planMemberChanges(t *testing.T) {
+	current := []memberResponse{
+		{Email: "owner@example.org", Role: "owner"},
+		{Email: "Admin@example.org", Role: "admin", Groups: []string{"g1"}},
+		{Email: "left@example.org", Role: "member"},
+		{Email: "breakglass@example.org", Role: "owner"},
+	}
+
+	t.Run("Plans invitations, updates and removals", func(t *testing.T) {
+		desired := []memberRequest{
+			{Email: "owner@example.org", Role: "owner"},
+			{Email: "admin@example.org", Role: "admin", Groups: []string{"g2"}},
+			{Email: "new@example.org", Role: "readonly"},
+		}
+
+		changes, err := planMemberChanges(current, desired, nil, "")
+		assert.Nil(t, err, "No errors")
+		assert.Equal(t, []memberRequest{desired[2]}, changes.invite)
+		assert.Equal(t, map[string]memberPutRequest{
+			"Admin@example.org": {Role: "admin", Groups: []string{"g2"}},
+		}, changes.update)
+		assert.Equal(t, []string{"breakglass@example.org", "left@example.org"}, changes.remove)
+	})
+
+	t.Run("Leaves allowlisted members untouched", func(t *testing.T) {
+		desired := []memberRequest{
+			{Email: "owner@example.org", Role: "owner"},
+			{Email: "admin@example.org", Role: "admin", Groups: []string{"g1"}},
+		}
+
+		changes, err := planMemberChanges(current, desired, []string{"BreakGlass@example.org"}, "")
+		assert.Nil(t, err, "No errors")
+		assert.Empty(t, changes.invite)
+		assert.Empty(t, changes.update)
+		assert.Equal(t, []string{"left@example.org"}, changes.remove)
+	})
+
+	t.Run("Refuses to remove the last owner", func(t *testing.T) {
+		desired := []memberRequest{
+			{Email: "admin@example.org", Role: "admin", Groups: []string{"g1"}},
+			{Email: "new-owner@example.org", Role: "owner"},
+		}
+
+		_, err := planMemberChanges(current, desired, nil, "")
+		assert.Regexp(t, "refusing to remove the last owner", err, "An invited owner does not count")
+
+		_, err = planMemberChanges(current, desired, []string{"breakglass@example.org"}, "")
+		assert.Nil(t, err, "An allowlisted owner is kept")
+	})
+
+	t.Run("Plans owner promotions and demotions", func(t *testing.T) {
+		changes, err := planMemberChanges(current, []memberRequest{
+			{Email: "owner@example.org", Role: "admin"},
+			{Email: "admin@example.org", Role: "owner", Groups: []string{"g1"}},
+		}, []string{"breakglass@example.org", "left@example.org"}, "")
+		assert.Nil(t, err, "No errors")
+		assert.Equal(t, map[string]memberPutRequest{
+			"owner@example.org": {Role: "admin"},
+			"Admin@example.org": {Role: "owner", Groups: []string{"g1"}},
+		}, changes.update)
+
+		_, err = planMemberChanges(current, []memberRequest{
+			{Email: "owner@example.org", Role: "admin"},
+			{Email: "admin@example.org", Role: "owner", Groups: []string{"g1"}},
+		}, nil, "")
+		assert.Regexp(t, "refusing to remove the last owner", err, "A promoted member does not count")
+	})
+
+	t.Run("Refuses at plan time", func(t *testing.T) {
+		ts, pc := mockListServer(t, map[string]interface{}{
+			"/v1/config/members": current,
+		})
+		defer ts.Close()
+
+		cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"member": []interface{}{
+				map[string]interface{}{"email": "new-owner@example.org", "role": "owner"},
+			},
+		})
+		_, err := resourceMembers().Diff(context.Background(), nil, cfg, pc)
+		assert.Regexp(t, "refusing to remove the last owner", err)
+	})
+
+	t.Run("Refuses removals when the provider's own member is unknown", func(t *testing.T) {
+		ts, pc := mockListServer(t, map[string]interface{}{
+			"/v1/config/members": current,
+		})
+		defer ts.Close()
+		pc.serviceKey = "abc123"
+
+		cfg := map[string]interface{}{
+			"member": []interface{}{
+				map[string]interface{}{"email": "owner@example.org", "role": "owner"},
+				map[string]interface{}{"email": "admin@example.org", "role": "admin", "groups": []interface{}{"g1"}},
+			},
+			"allowlist": []interface{}{"breakglass@example.org"},
+		}
+		_, err := resourceMembers().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(cfg), pc)
+		assert.Regexp(t, "refusing to remove left@example.org, the member the provider credentials belong to is unknown", err)
+
+		cfg["protected_member"] = "breakglass@example.org"
+		_, err = resourceMembers().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(cfg), pc)
+		assert.Nil(t, err, "The protected member is allowlisted")
+
+		cfg["protected_member"] = "left@example.org"
+		_, err = resourceMembers().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(cfg), pc)
+		assert.Regexp(t, "refusing to remove left@example.org, the member whose credentials", err)
+	})
+
+	t.Run("Refuses to remove the provider's own member", func(t *testing.T) {
+		desired := []memberRequest{
+			{Email: "owner@example.org", Role: "owner"},
+		}
+
+		_, err := planMemberChanges(current, desired, []string{"breakglass@example.org"}, "LEFT@example.org")
+		assert.Regexp(t, "refusing to remove left@example.org, the member whose credentials", err)
+	})
+}
+
+func TestMembers_iamTokenEmail(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"email":"me@example.org"}`))
+
+	assert.Equal(t, "me@example.org", iamTokenEmail("header."+payload+".signature"))
+	assert.Equal(t, "me@example.org", iamTokenEmail("Bearer header."+payload+".signature"))
+	assert.Equal(t, "", iamTokenEmail("not-a-token"))
+	assert.Equal(t, "", iamTokenEmail("header.!!!.signature"))
+
+	assert.Equal(t, "me@example.org", providerMemberEmail(&providerConfig{iamtoken: "header." + payload + ".signature"}))
+	assert.Equal(t, "", providerMemberEmail(&providerConfig{serviceKey: "abc123", iamtoken: "header." + payload + ".signature"}), "Requests use the service key")
+}
+
+func TestMembers_ErrorsResourceFields(t *testing.T) {
+	dupArgs := map[string]map[string]string{
+		"member": {
+			"email": `"user@example.org"`,
+			"role":  `"member"`,
+		},
+		"member1": {
+			"email": `"USER@example.org"`,
+			"role":  `"admin"`,
+		},
+	}
+	allowArgs := map[string]string{
+		"allowlist": `["user@example.org"]`,
+	}
+	roleArgs := map[string]map[string]string{
+		"member": {
+			"email": `"user@example.org"`,
+			"role":  `"superuser"`,
+		},
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmtTestConfigResource("members", "all", globalPcArgs, map[string]string{}, dupArgs, nilLst),
+				ExpectError: regexp.MustCompile("is declared more than once"),
+			},
+			{
+				Config:      fmtTestConfigResource("members", "all", globalPcArgs, allowArgs, roleArgs, nilLst),
+				ExpectError: regexp.MustCompile(`role\" to not be an empty string`),
+			},
+		},
+	})
+}
+
+func TestMembers_Basic(t *testing.T) {
+	iniArgs := map[string]map[string]string{
+		"member": {
+			"email": `"member@example.org"`,
+			"role":  `"member"`,
+		},
+	}
+	updArgs := map[string]map[string]string{
+		"member": {
+			"email": `"member@example.org"`,
+			"role":  `"admin"`,
+		},
+		"member1": {
+			"email": `"readonly@example.org"`,
+			"role":  `"readonly"`,
+		},
+	}
+	// The owner of the test account must stay in the organization
+	rsArgs := map[string]string{
+		"allowlist":        `["owner@example.org"]`,
+		"protected_member": `"owner@example.org"`,
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmtTestConfigResource("members", "all", globalPcArgs, rsArgs, iniArgs, nilLst),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("members", "all"),
+					resource.TestCheckResourceAttr("logdna_members.all", "member.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("logdna_members.all", "member.*", map[string]string{
+						"email": "member@example.org",
+						"role":  "member",
+					}),
+				),
+			},
+			{
+				Config: fmtTestConfigResource("members", "all", globalPcArgs, rsArgs, updArgs, nilLst),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logdna_members.all", "member.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("logdna_members.all", "member.*", map[string]string{
+						"email": "member@example.org",
+						"role":  "admin",
+					}),
+				),
+			},
+			{
+				ResourceName:            "logdna_members.all",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allowlist", "member"},
+			},
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package logdna
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRole_ErrorsResourceFields(t *testing.T) {
+	builtinArgs := map[string]string{
+		"name":        `"Admin"`,
+		"permissions": `["views.read"]`,
+	}
+	emptyArgs := map[string]string{
+		"name":        `"test"`,
+		"permissions": `[]`,
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmtTestConfigResource("role", "new", globalPcArgs, builtinArgs, nilOpt, nilLst),
+				ExpectError: regexp.MustCompile(`expected name to not be any of \[owner admin member readonly\]`),
+			},
+			{
+				Config:      fmtTestConfigResource("role", "new", globalPcArgs, emptyArgs, nilOpt, nilLst),
+				ExpectError: regexp.MustCompile("Attribute requires 1 item minimum"),
+			},
+		},
+	})
+}
+
+func TestRole_Basic(t *testing.T) {
+	iniArgs := map[string]string{
+		"name":        `"test"`,
+		"permissions": `["views.read", "boards.read"]`,
+	}
+	updArgs := map[string]string{
+		"name":        `"test2"`,
+		"description": `"Read-only access to views"`,
+		"permissions": `["views.read"]`,
+	}
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmtTestConfigResource("role", "new", globalPcArgs, iniArgs, nilOpt, nilLst),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("role", "new"),
+					resource.TestCheckResourceAttr("logdna_role.new", "name", "test"),
+					resource.TestCheckResourceAttr("logdna_role.new", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("logdna_role.new", "permissions.*", "boards.read"),
+				),
+			},
+			{
+				Config: fmtTestConfigResource("role", "new", globalPcArgs, updArgs, nilOpt, nilLst),
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("role", "new"),
+					resource.TestCheckResourceAttr("logdna_role.new", "name", "test2"),
+					resource.TestCheckResourceAttr("logdna_role.new", "description", "Read-only access to views"),
+					resource.TestCheckResourceAttr("logdna_role.new", "permissions.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "logdna_role.new",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,390 @@
+package logdna
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Each widget type is configured with its own `<type>_widget` block
+var screenWidgetTypes = []string{"counter", "gauge", "table", "timeseries"}
+
+func resourceScreenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+
+	screen := screenRequest{}
+
+	if diags = screen.CreateRequestBody(d); diags.HasError() {
+		return diags
+	}
+	names, catDiags := resolveCategoryIds(pc, d, "screens")
+	diags = append(diags, catDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if names != nil {
+		screen.Category = names
+	}
+
+	req := newRequestConfig(
+		pc,
+		"POST",
+		"/v1/config/screen",
+		screen,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createdScreen := screenResponse{}
+	err = json.Unmarshal(body, &createdScreen)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[DEBUG] After %s screen, the created screen is %+v", req.method, createdScreen)
+
+	d.SetId(createdScreen.ScreenID)
+
+	return resourceScreenRead(ctx, d, m)
+}
+
+func resourceScreenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	pc := m.(*providerConfig)
+	screenID := d.Id()
+
+	req := newRequestConfig(
+		pc,
+		"GET",
+		fmt.Sprintf("/v1/config/screen/%s", screenID),
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+
+	log.Printf("[DEBUG] GET screen raw response body %s\n", body)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot read the remote screen resource",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	screen := screenResponse{}
+	err = json.Unmarshal(body, &screen)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot unmarshal response from the remote screen resource",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	log.Printf("[DEBUG] The GET screen structure is as follows: %+v\n", screen)
+
+	// Top level keys can be set directly
+	appendError(d.Set("name", screen.Name), &diags)
+	if diags = append(diags, setCategories(pc, d, "screens", screen.Category)...); diags.HasError() {
+		return diags
+	}
+
+	// Widgets missing from the response are removed from the state, so that
+	// widgets deleted in the web UI are recreated
+	for widgetType, widgets := range screen.MapWidgetsToSchema() {
+		appendError(d.Set(fmt.Sprintf("%s_widget", widgetType), widgets), &diags)
+	}
+
+	return diags
+}
+
+func resourceScreenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pc := m.(*providerConfig)
+	screenID := d.Id()
+	screen := screenRequest{}
+
+	if diags = screen.CreateRequestBody(d); diags.HasError() {
+		return diags
+	}
+	names, catDiags := resolveCategoryIds(pc, d, "screens")
+	diags = append(diags, catDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if names != nil {
+		screen.Category = names
+	}
+
+	req := newRequestConfig(
+		pc,
+		"PUT",
+		fmt.Sprintf("/v1/config/screen/%s", screenID),
+		screen,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s, payload is: %s", req.method, req.apiURL, body)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] %s %s SUCCESS. Remote resource updated.", req.method, req.apiURL)
+
+	return resourceScreenRead(ctx, d, m)
+}
+
+func resourceScreenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+	screenID := d.Id()
+
+	req := newRequestConfig(
+		pc,
+		"DELETE",
+		fmt.Sprintf("/v1/config/screen/%s", screenID),
+		nil,
+	)
+
+	body, err := req.MakeRequest()
+	log.Printf("[DEBUG] %s %s screen %s", req.method, req.apiURL, body)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+type screenWidgetRect struct {
+	key                 string
+	x, y, width, height int
+}
+
+func (r screenWidgetRect) overlaps(o screenWidgetRect) bool {
+	return r.x < o.x+o.width && o.x < r.x+r.width && r.y < o.y+o.height && o.y < r.y+r.height
+}
+
+// customizeScreenDiff checks that aggregations other than `count` have a field
+// to aggregate over and that no two widgets overlap on the screen
+func customizeScreenDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	rects := []screenWidgetRect{}
+
+	for _, widgetType := range screenWidgetTypes {
+		blockName := fmt.Sprintf("%s_widget", widgetType)
+		for i, w := range d.Get(blockName).([]interface{}) {
+			widget, _ := w.(map[string]interface{})
+			if widget == nil {
+				continue
+			}
+			key := fmt.Sprintf("%s.%d", blockName, i)
+
+			if aggregation, ok := widget["aggregation"].(string); ok && d.NewValueKnown(key+".aggregation") && d.NewValueKnown(key+".field") {
+				if aggregation != "count" && widget["field"].(string) == "" {
+					return fmt.Errorf("%s: the %s aggregation requires a field", key, aggregation)
+				}
+			}
+
+			if widgetType == "gauge" && d.NewValueKnown(key+".min") && d.NewValueKnown(key+".max") {
+				if min, max := widget["min"].(float64), widget["max"].(float64); max <= min {
+					return fmt.Errorf("%s: max (%g) must be greater than min (%g)", key, max, min)
+				}
+			}
+
+			layouts, _ := widget["layout"].([]interface{})
+			if len(layouts) == 0 || layouts[0] == nil || !d.NewValueKnown(key+".layout") {
+				continue
+			}
+			layout := layouts[0].(map[string]interface{})
+			rect := screenWidgetRect{
+				key:    key,
+				x:      layout["x"].(int),
+				y:      layout["y"].(int),
+				width:  layout["width"].(int),
+				height: layout["height"].(int),
+			}
+			for _, other := range rects {
+				if rect.overlaps(other) {
+					return fmt.Errorf("%s overlaps with %s, widgets cannot share the same position on a screen", key, other.key)
+				}
+			}
+			rects = append(rects, rect)
+		}
+	}
+	return nil
+}
+
+// screenWidgetSchema returns the arguments shared by every widget along with
+// the ones specific to the given widget type
+func screenWidgetSchema(widgetType string) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"widgetid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"title": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"query": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateQuery,
+			DiffSuppressFunc: suppressEquivalentQuery,
+		},
+		"layout": {
+			Type:     schema.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"x": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"y": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					"width": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"height": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		},
+	}
+
+	aggregation := map[string]*schema.Schema{
+		"aggregation": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "count",
+			ValidateFunc: validation.StringInSlice(plotAggregations, false),
+		},
+		"field": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+
+	switch widgetType {
+	case "counter":
+		for k, v := range aggregation {
+			s[k] = v
+		}
+	case "gauge":
+		for k, v := range aggregation {
+			s[k] = v
+		}
+		s["min"] = &schema.Schema{
+			Type:     schema.TypeFloat,
+			Optional: true,
+			Default:  0,
+		}
+		s["max"] = &schema.Schema{
+			Type:     schema.TypeFloat,
+			Required: true,
+		}
+	case "table":
+		s["columns"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		}
+		s["limit"] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			ValidateFunc: validation.IntBetween(1, 100),
+		}
+	case "timeseries":
+		for k, v := range aggregation {
+			s[k] = v
+		}
+		s["group_by"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		}
+		s["time_range"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "1h",
+			ValidateFunc: validation.StringMatch(boardTimeRangeExp, "must be a duration such as 15m, 1h, 7d or 2w"),
+		}
+	}
+
+	return s
+}
+
+func resourceScreen() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"categories": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			Elem:          &schema.Schema{Type: schema.TypeString},
+			ConflictsWith: []string{"category_ids"},
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new)
+			},
+		},
+		"category_ids": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateCategoryIdOfType("screens"),
+			},
+			ConflictsWith: []string{"categories"},
+		},
+	}
+
+	for _, widgetType := range screenWidgetTypes {
+		s[fmt.Sprintf("%s_widget", widgetType)] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: screenWidgetSchema(widgetType),
+			},
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceScreenCreate,
+		ReadContext:   resourceScreenRead,
+		UpdateContext: resourceScreenUpdate,
+		DeleteContext: resourceScreenDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importScreenState,
+		},
+		CustomizeDiff: customizeScreenDiff,
+		Schema:        s,
+	}
+}
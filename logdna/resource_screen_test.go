@@ -0,0 +1,242 @@
+package logdna
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func testScreenConfig(body string) string {
+	return fmt.Sprintf(`%s
+resource "logdna_category" "screens" {
+	name = "DemoScreenCategory"
+	type = "screens"
+}
+
+resource "logdna_screen" "new" {
+%s
+}`, fmtProviderBlock(globalPcArgs...), body)
+}
+
+func TestScreen_widgetOverlaps(t *testing.T) {
+	assert := assert.New(t)
+	a := screenWidgetRect{"a", 0, 0, 4, 2}
+
+	assert.True(a.overlaps(screenWidgetRect{"b", 3, 1, 4, 2}), "Overlapping corner")
+	assert.True(a.overlaps(screenWidgetRect{"b", 1, 0, 1, 1}), "Contained widget")
+	assert.False(a.overlaps(screenWidgetRect{"b", 4, 0, 4, 2}), "Adjacent columns")
+	assert.False(a.overlaps(screenWidgetRect{"b", 0, 2, 4, 2}), "Adjacent rows")
+}
+
+func TestScreen_customizeScreenDiff(t *testing.T) {
+	gauge := func(min, max float64) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "test",
+			"gauge_widget": []interface{}{map[string]interface{}{
+				"min":    min,
+				"max":    max,
+				"layout": []interface{}{map[string]interface{}{"x": 0, "y": 0, "width": 2, "height": 2}},
+			}},
+		})
+	}
+
+	_, err := resourceScreen().Diff(context.Background(), nil, gauge(0, 500), nil)
+	assert.Nil(t, err, "No errors")
+
+	_, err = resourceScreen().Diff(context.Background(), nil, gauge(10, 5), nil)
+	assert.EqualError(t, err, "gauge_widget.0: max (5) must be greater than min (10)")
+}
+
+func TestScreen_ErrorsResourceFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testScreenConfig(`
+	name = "test"
+	gauge_widget {
+		query = "app:api"
+		layout {
+			x      = 0
+			y      = 0
+			width  = 2
+			height = 2
+		}
+	}`),
+				ExpectError: regexp.MustCompile(`The argument "max" is required, but no definition was found.`),
+			},
+			{
+				Config: testScreenConfig(`
+	name = "test"
+	counter_widget {
+		aggregation = "sum"
+		layout {
+			x      = 0
+			y      = 0
+			width  = 2
+			height = 2
+		}
+	}`),
+				ExpectError: regexp.MustCompile("counter_widget.0: the sum aggregation requires a field"),
+			},
+			{
+				Config: testScreenConfig(`
+	name = "test"
+	gauge_widget {
+		min = 100
+		max = 100
+		layout {
+			x      = 0
+			y      = 0
+			width  = 2
+			height = 2
+		}
+	}`),
+				ExpectError: regexp.MustCompile(`gauge_widget.0: max \(100\) must be greater than min \(100\)`),
+			},
+			{
+				Config: testScreenConfig(`
+	name = "test"
+	counter_widget {
+		layout {
+			x      = 0
+			y      = 0
+			width  = 4
+			height = 2
+		}
+	}
+	table_widget {
+		columns = ["app"]
+		layout {
+			x      = 2
+			y      = 1
+			width  = 4
+			height = 4
+		}
+	}`),
+				ExpectError: regexp.MustCompile("table_widget.0 overlaps with counter_widget.0"),
+			},
+			{
+				Config: testScreenConfig(`
+	name         = "test"
+	category_ids = ["boards:abc123"]`),
+				ExpectError: regexp.MustCompile("must reference a category of type screens, got: boards"),
+			},
+		},
+	})
+}
+
+func TestScreen_Basic(t *testing.T) {
+	iniCfg := testScreenConfig(`
+	name         = "test"
+	category_ids = [logdna_category.screens.id]
+
+	counter_widget {
+		title = "Errors"
+		query = "level:error"
+		layout {
+			x      = 0
+			y      = 0
+			width  = 2
+			height = 2
+		}
+	}`)
+
+	updCfg := testScreenConfig(`
+	name         = "test2"
+	category_ids = [logdna_category.screens.id]
+
+	counter_widget {
+		title = "Errors"
+		query = "level:error"
+		layout {
+			x      = 0
+			y      = 0
+			width  = 2
+			height = 2
+		}
+	}
+
+	gauge_widget {
+		title       = "Average response time"
+		query       = "app:api"
+		aggregation = "avg"
+		field       = "response_time"
+		max         = 500
+		layout {
+			x      = 2
+			y      = 0
+			width  = 2
+			height = 2
+		}
+	}
+
+	table_widget {
+		query   = "level:error"
+		columns = ["app", "host"]
+		limit   = 20
+		layout {
+			x      = 0
+			y      = 2
+			width  = 4
+			height = 3
+		}
+	}
+
+	timeseries_widget {
+		query      = "app:api"
+		group_by   = ["host"]
+		time_range = "24h"
+		layout {
+			x      = 4
+			y      = 0
+			width  = 4
+			height = 5
+		}
+	}`)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: iniCfg,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("screen", "new"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "name", "test"),
+					resource.TestCheckTypeSetElemAttrPair("logdna_screen.new", "category_ids.*", "logdna_category.screens", "id"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "counter_widget.#", "1"),
+					resource.TestCheckResourceAttrSet("logdna_screen.new", "counter_widget.0.widgetid"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "counter_widget.0.aggregation", "count"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "counter_widget.0.layout.0.width", "2"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "gauge_widget.#", "0"),
+				),
+			},
+			{
+				Config: updCfg,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("screen", "new"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "name", "test2"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "counter_widget.#", "1"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "gauge_widget.#", "1"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "gauge_widget.0.min", "0"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "gauge_widget.0.max", "500"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "table_widget.0.columns.#", "2"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "table_widget.0.limit", "20"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "timeseries_widget.0.time_range", "24h"),
+					resource.TestCheckResourceAttr("logdna_screen.new", "timeseries_widget.0.group_by.0", "host"),
+				),
+			},
+			{
+				ResourceName:            "logdna_screen.new",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"category_ids", "categories"},
+			},
+		},
+	})
+}
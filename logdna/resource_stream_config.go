@@ -3,31 +3,142 @@ package logdna
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const streamConfigID = "stream"
 
+// Overridden by tests to avoid waiting between polls
+var streamConfigPollInterval = 5 * time.Second
+
 type streamConfig struct {
-	Status   string   `json:"status,omitempty"`
-	Brokers  []string `json:"brokers"`
-	Topic    string   `json:"topic"`
-	User     string   `json:"user"`
-	Password string   `json:"password"`
+	Status        string           `json:"status,omitempty"`
+	Reason        string           `json:"reason,omitempty"`
+	Brokers       []string         `json:"brokers"`
+	Topic         string           `json:"topic"`
+	User          string           `json:"user"`
+	Password      string           `json:"password"`
+	SASLMechanism string           `json:"sasl_mechanism,omitempty"`
+	Compression   string           `json:"compression,omitempty"`
+	TLS           *streamTLSConfig `json:"tls,omitempty"`
+}
+
+type streamTLSConfig struct {
+	CACert     string `json:"ca_cert,omitempty"`
+	ServerName string `json:"server_name,omitempty"`
+}
+
+func streamConfigFromSchema(d *schema.ResourceData) streamConfig {
+	c := streamConfig{
+		Brokers:       listToStrings(d.Get("brokers").([]interface{})),
+		Topic:         d.Get("topic").(string),
+		User:          d.Get("user").(string),
+		Password:      secretFromConfig(d, "", "password"),
+		SASLMechanism: d.Get("sasl_mechanism").(string),
+		Compression:   d.Get("compression").(string),
+	}
+
+	if tls := d.Get("tls").([]interface{}); len(tls) > 0 && tls[0] != nil {
+		settings := tls[0].(map[string]interface{})
+		c.TLS = &streamTLSConfig{
+			CACert:     settings["ca_pem"].(string),
+			ServerName: settings["server_name"].(string),
+		}
+	}
+
+	return c
+}
+
+func (c *streamConfig) MapTLSToSchema() []interface{} {
+	if c.TLS == nil {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"ca_pem":      c.TLS.CACert,
+		"server_name": c.TLS.ServerName,
+	}}
+}
+
+// validateBroker requires brokers to be given as host:port, without a scheme
+func validateBroker(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	host, port, err := net.SplitHostPort(v)
+	if err != nil || host == "" {
+		errs = append(errs, fmt.Errorf("%q must be a broker address such as broker-1.example.org:9093, got: %q", key, v))
+		return
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		errs = append(errs, fmt.Errorf("%q must have a port between 1 and 65535, got: %q", key, v))
+	}
+	return
+}
+
+// validateCACert requires at least one PEM encoded certificate
+func validateCACert(val interface{}, key string) (warns []string, errs []error) {
+	block, _ := pem.Decode([]byte(val.(string)))
+	if block == nil || block.Type != "CERTIFICATE" {
+		errs = append(errs, fmt.Errorf("%q must contain a PEM encoded CERTIFICATE", key))
+	}
+	return
+}
+
+// waitForStreamConfig polls the stream configuration until the connection to
+// the brokers has been verified. Brokers and credentials are checked
+// asynchronously, so a failure is only reported through the status.
+func waitForStreamConfig(ctx context.Context, pc *providerConfig, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"pending", "verifying"},
+		Target:       []string{"active"},
+		Timeout:      timeout,
+		PollInterval: streamConfigPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			req := newRequestConfig(
+				pc,
+				"GET",
+				"/v1/config/stream",
+				nil,
+			)
+
+			body, err := req.MakeRequest()
+			log.Printf("[DEBUG] GET stream config raw response body %s\n", body)
+			if err != nil {
+				return nil, "", err
+			}
+
+			c := streamConfig{}
+			if err = json.Unmarshal(body, &c); err != nil {
+				return nil, "", err
+			}
+			if c.Status == "" {
+				// Without a status, there is nothing left to verify
+				c.Status = "active"
+			}
+			if c.Status == "failed" {
+				return nil, "", fmt.Errorf("the stream configuration failed: %s", c.Reason)
+			}
+			return c, c.Status, nil
+		},
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
 }
 
 func resourceStreamConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	pc := m.(*providerConfig)
-	c := streamConfig{
-		Brokers:  listToStrings(d.Get("brokers").([]interface{})),
-		Topic:    d.Get("topic").(string),
-		User:     d.Get("user").(string),
-		Password: d.Get("password").(string),
-	}
+	c := streamConfigFromSchema(d)
 
 	req := newRequestConfig(
 		pc,
@@ -48,12 +159,17 @@ func resourceStreamConfigCreate(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	d.SetId(streamConfigID)
-	appendError(d.Set("brokers", cn.Brokers), &diags)
-	appendError(d.Set("topic", cn.Topic), &diags)
-	appendError(d.Set("user", cn.User), &diags)
-	appendError(d.Set("status", cn.Status), &diags)
 
-	return diags
+	if err := waitForStreamConfig(ctx, pc, d.Timeout(schema.TimeoutCreate)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "The stream configuration did not become active",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	return resourceStreamConfigRead(ctx, d, m)
 }
 
 func resourceStreamConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -92,19 +208,30 @@ func resourceStreamConfigRead(ctx context.Context, d *schema.ResourceData, m int
 	appendError(d.Set("brokers", c.Brokers), &diags)
 	appendError(d.Set("topic", c.Topic), &diags)
 	appendError(d.Set("user", c.User), &diags)
+	// The password is never read back, the state keeps a hash of the applied one
+	password, err := storedSecret(d, "password")
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Cannot hash the password of the stream config",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	appendError(d.Set("password", password), &diags)
+	appendError(d.Set("sasl_mechanism", c.SASLMechanism), &diags)
+	appendError(d.Set("compression", c.Compression), &diags)
+	appendError(d.Set("tls", c.MapTLSToSchema()), &diags)
 	appendError(d.Set("status", c.Status), &diags)
 
 	return diags
 }
 
 func resourceStreamConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
 	pc := m.(*providerConfig)
-	c := streamConfig{
-		Brokers:  listToStrings(d.Get("brokers").([]interface{})),
-		Topic:    d.Get("topic").(string),
-		User:     d.Get("user").(string),
-		Password: d.Get("password").(string),
-	}
+	c := streamConfigFromSchema(d)
 
 	req := newRequestConfig(
 		pc,
@@ -118,6 +245,15 @@ func resourceStreamConfigUpdate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	if err := waitForStreamConfig(ctx, pc, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "The stream configuration did not become active",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
 	return resourceStreamConfigRead(ctx, d, m)
 }
 
@@ -148,6 +284,10 @@ func resourceStreamConfig() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"status": {
@@ -155,8 +295,11 @@ func resourceStreamConfig() *schema.Resource {
 				Computed: true,
 			},
 			"brokers": {
-				Type:     schema.TypeList,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateBroker,
+				},
 				MinItems: 1,
 				Required: true,
 			},
@@ -169,9 +312,40 @@ func resourceStreamConfig() *schema.Resource {
 				Required: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressSecretDiff,
+			},
+			"sasl_mechanism": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"}, false),
+			},
+			"compression": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"none", "gzip", "snappy", "lz4", "zstd"}, false),
+			},
+			"tls": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ca_pem": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCACert,
+						},
+						"server_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -13,13 +13,7 @@ func resourceStreamExclusionCreate(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 
 	pc := m.(*providerConfig)
-	ex := exclusionRule{
-		Title:  d.Get("title").(string),
-		Active: d.Get("active").(bool),
-		Apps:   listToStrings(d.Get("apps").([]interface{})),
-		Hosts:  listToStrings(d.Get("hosts").([]interface{})),
-		Query:  d.Get("query").(string),
-	}
+	ex := exclusionRuleFromSchema(d)
 
 	req := newRequestConfig(
 		pc,
@@ -40,11 +34,7 @@ func resourceStreamExclusionCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	d.SetId(exn.ID)
-	appendError(d.Set("title", exn.Title), &diags)
-	appendError(d.Set("active", exn.Active), &diags)
-	appendError(d.Set("apps", exn.Apps), &diags)
-	appendError(d.Set("hosts", exn.Hosts), &diags)
-	appendError(d.Set("query", exn.Query), &diags)
+	setExclusionRule(d, exn, &diags)
 
 	return diags
 }
@@ -81,24 +71,14 @@ func resourceStreamExclusionRead(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	appendError(d.Set("title", ex.Title), &diags)
-	appendError(d.Set("active", ex.Active), &diags)
-	appendError(d.Set("apps", ex.Apps), &diags)
-	appendError(d.Set("hosts", ex.Hosts), &diags)
-	appendError(d.Set("query", ex.Query), &diags)
+	setExclusionRule(d, ex, &diags)
 
 	return diags
 }
 
 func resourceStreamExclusionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
-	ex := exclusionRule{
-		Title:  d.Get("title").(string),
-		Active: d.Get("active").(bool),
-		Apps:   listToStrings(d.Get("apps").([]interface{})),
-		Hosts:  listToStrings(d.Get("hosts").([]interface{})),
-		Query:  d.Get("query").(string),
-	}
+	ex := exclusionRuleFromSchema(d)
 
 	req := newRequestConfig(
 		pc,
@@ -139,6 +119,7 @@ func resourceStreamExclusion() *schema.Resource {
 		ReadContext:   resourceStreamExclusionRead,
 		UpdateContext: resourceStreamExclusionUpdate,
 		DeleteContext: resourceStreamExclusionDelete,
+		CustomizeDiff: customizeExclusionDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -29,6 +29,14 @@ func resourceViewCreate(ctx context.Context, d *schema.ResourceData, m interface
 	if diags = view.CreateRequestBody(d); diags.HasError() {
 		return diags
 	}
+	names, catDiags := resolveCategoryIds(pc, d, "views")
+	diags = append(diags, catDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if names != nil {
+		view.Category = names
+	}
 
 	req := newRequestConfig(
 		pc,
@@ -95,25 +103,41 @@ func resourceViewRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	// Top level keys can be set directly
 	appendError(d.Set("name", view.Name), &diags)
-	appendError(d.Set("query", view.Query), &diags)
-	appendError(d.Set("categories", view.Category), &diags)
+	appendError(d.Set("rendered_query", view.Query), &diags)
+	// NOTE query stays empty when the remote query is generated from query_filter
+	if len(d.Get("query_filter").([]interface{})) == 0 {
+		appendError(d.Set("query", view.Query), &diags)
+	}
+	if diags = append(diags, setCategories(pc, d, "views", view.Category)...); diags.HasError() {
+		return diags
+	}
 	appendError(d.Set("hosts", view.Hosts), &diags)
 	appendError(d.Set("tags", view.Tags), &diags)
 	appendError(d.Set("apps", view.Apps), &diags)
 	appendError(d.Set("levels", view.Levels), &diags)
-	// NOTE There is always one element in the PresetIds slice
-	appendError(d.Set("presetid", strings.Join(view.PresetIds, "")), &diags)
+	appendError(d.Set("presetids", view.PresetIds), &diags)
+
+	// NOTE presetid is deprecated, so it is only kept up to date for
+	//      configurations which still use it
+	if d.Get("presetid").(string) != "" {
+		presetID := ""
+		if len(view.PresetIds) == 1 {
+			presetID = view.PresetIds[0]
+		}
+		appendError(d.Set("presetid", presetID), &diags)
+	}
 
 	// NOTE API does DB denormalization and extend a view record in DB
 	//      with a alert channels which break a schema validation here.
-	//      We don't need the channels field in case when a presetid exists
-	if len(d.Get("presetid").(string)) > 0 {
+	//      We don't need the channels field in case when a preset exists
+	if len(view.PresetIds) > 0 {
 		return diags
 	}
 
 	// Convert types to maps for setting the schema
 	integrations, diags := view.MapChannelsToSchema()
 	log.Printf("[DEBUG] view MapChannelsToSchema result: %+v\n", integrations)
+	splitSensitiveHeaders(d, integrations[WEBHOOK])
 
 	// Store the channel responses in the schema - note that this should also NUKE missing
 	// integrations since we have done a PUT operation. Thus, remove non-existing things.
@@ -134,6 +158,14 @@ func resourceViewUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	if diags = view.CreateRequestBody(d); diags.HasError() {
 		return diags
 	}
+	names, catDiags := resolveCategoryIds(pc, d, "views")
+	diags = append(diags, catDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if names != nil {
+		view.Category = names
+	}
 
 	req := newRequestConfig(
 		pc,
@@ -175,6 +207,31 @@ func resourceViewDelete(ctx context.Context, d *schema.ResourceData, m interface
 	return nil
 }
 
+// customizeViewDiff plans the query that will be sent to the API, so that the
+// result of query_filter is shown in the plan and remote drift is corrected
+func customizeViewDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("query") || !d.NewValueKnown("query_filter") {
+		return d.SetNewComputed("rendered_query")
+	}
+
+	filters := d.Get("query_filter").([]interface{})
+	if len(filters) == 0 {
+		if o, n := d.GetChange("query"); !equivalentQueries(o.(string), n.(string)) {
+			return d.SetNew("rendered_query", n.(string))
+		}
+		return nil
+	}
+
+	rendered, err := renderQueryFilter(filters)
+	if err != nil {
+		return err
+	}
+	if old, _ := d.GetChange("rendered_query"); old.(string) != rendered {
+		return d.SetNew("rendered_query", rendered)
+	}
+	return nil
+}
+
 func resourceView() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceViewCreate,
@@ -182,8 +239,9 @@ func resourceView() *schema.Resource {
 		UpdateContext: resourceViewUpdate,
 		DeleteContext: resourceViewDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importViewState,
 		},
+		CustomizeDiff: customizeViewDiff,
 
 		Schema: map[string]*schema.Schema{
 			"apps": {
@@ -192,9 +250,10 @@ func resourceView() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"categories": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"category_ids"},
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					shouldSuppress := false
 					lowerCaseOld := strings.ToLower(old)
@@ -206,6 +265,15 @@ func resourceView() *schema.Resource {
 					return shouldSuppress
 				},
 			},
+			"category_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCategoryIdOfType("views"),
+				},
+				ConflictsWith: []string{"categories"},
+			},
 			"hosts": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -221,18 +289,52 @@ func resourceView() *schema.Resource {
 				Required: true,
 			},
 			"query": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateQuery,
+				DiffSuppressFunc: suppressEquivalentQuery,
+			},
+			"query_filter": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"query"},
+				Elem: &schema.Resource{
+					Schema: queryFilterSchema(maxQueryFilterDepth),
+				},
+			},
+			"rendered_query": {
 				Type:     schema.TypeString,
-				Optional: true,
+				Computed: true,
 			},
 			"presetid": {
-				Type:     schema.TypeString,
+				Type:       schema.TypeString,
+				Optional:   true,
+				Deprecated: "Use presetids instead",
+				ConflictsWith: []string{
+					"presetids",
+					"email_channel",
+					"pagerduty_channel",
+					"slack_channel",
+					"webhook_channel",
+				},
+			},
+			"presetids": {
+				Type:     schema.TypeSet,
 				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 				ConflictsWith: []string{
+					"presetid",
 					"email_channel",
 					"pagerduty_channel",
 					"slack_channel",
 					"webhook_channel",
 				},
+				// NOTE The remote preset IDs are always read into presetids, which
+				//      should not produce a diff for configurations using presetid
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Get("presetid").(string) != ""
+				},
 			},
 			"tags": {
 				Type:     schema.TypeList,
@@ -244,13 +346,7 @@ func resourceView() *schema.Resource {
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"emails": {
-							Type:     schema.TypeList,
-							Required: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
+						"emails": emailListSchema(),
 						"immediate": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -299,8 +395,9 @@ func resourceView() *schema.Resource {
 							Default:  "false",
 						},
 						"key": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
 						},
 						"operator": {
 							Type:     schema.TypeString,
@@ -385,8 +482,9 @@ func resourceView() *schema.Resource {
 							},
 						},
 						"url": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
 						},
 					},
 				},
@@ -422,7 +520,16 @@ func resourceView() *schema.Resource {
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
-							Optional: true,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"sensitive_headers": {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:  true,
+							Sensitive: true,
 						},
 						"immediate": {
 							Type:     schema.TypeString,
@@ -47,6 +47,10 @@ func TestView_ErrorsResourceFields(t *testing.T) {
 	lvl["levels"] = `"invalid levels value"`
 	lvlCfg := fmtTestConfigResource("view", "new", globalPcArgs, lvl, nilOpt, nilLst)
 
+	qry := cloneDefaults(rsDefaults["view"])
+	qry["query"] = `"app:api AND"`
+	qryCfg := fmtTestConfigResource("view", "new", globalPcArgs, qry, nilOpt, nilLst)
+
 	tgs := cloneDefaults(rsDefaults["view"])
 	tgs["tags"] = `"invalid tags value"`
 	tgsCfg := fmtTestConfigResource("view", "new", globalPcArgs, tgs, nilOpt, nilLst)
@@ -78,6 +82,10 @@ func TestView_ErrorsResourceFields(t *testing.T) {
 				Config:      tgsCfg,
 				ExpectError: regexp.MustCompile("Inappropriate value for attribute \"tags\": list of string required."),
 			},
+			{
+				Config:      qryCfg,
+				ExpectError: regexp.MustCompile("expected a search term after AND at column 12"),
+			},
 		},
 	})
 }
@@ -277,6 +285,57 @@ func TestView_Basic(t *testing.T) {
 	})
 }
 
+func TestView_QueryFilter(t *testing.T) {
+	cfg := fmt.Sprintf(`%s
+resource "logdna_view" "new" {
+	name = "test"
+	query_filter {
+		and {
+			field = "app"
+			value = "api"
+		}
+		and {
+			or {
+				field = "level"
+				value = "error"
+			}
+			or {
+				field    = "response"
+				operator = "gte"
+				value    = "500"
+			}
+		}
+	}
+}`, fmtProviderBlock(globalPcArgs...))
+
+	conflictCfg := fmt.Sprintf(`%s
+resource "logdna_view" "new" {
+	name  = "test"
+	query = "test"
+	query_filter {
+		value = "test"
+	}
+}`, fmtProviderBlock(globalPcArgs...))
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      conflictCfg,
+				ExpectError: regexp.MustCompile("Error: Conflicting configuration arguments"),
+			},
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("view", "new"),
+					resource.TestCheckResourceAttr("logdna_view.new", "query", ""),
+					resource.TestCheckResourceAttr("logdna_view.new", "rendered_query", "app:api AND (level:error OR response:>=500)"),
+				),
+			},
+		},
+	})
+}
+
 func TestView_BulkChannels(t *testing.T) {
 	emArgs := map[string]map[string]string{
 		"email_channel":  cloneDefaults(chnlDefaults["email_channel"]),
@@ -354,8 +413,8 @@ func TestView_BulkChannels(t *testing.T) {
 					resource.TestCheckResourceAttr("logdna_view.new", "name", "test"),
 					resource.TestCheckResourceAttr("logdna_view.new", "query", "test"),
 					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.#", "2"),
-					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.0.%", "9"),
-					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.1.%", "9"),
+					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.0.%", "10"),
+					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.1.%", "10"),
 					resource.TestCheckResourceAttr("logdna_view.new", "email_channel.#", "0"),
 					resource.TestCheckResourceAttr("logdna_view.new", "pagerduty_channel.#", "0"),
 					resource.TestCheckResourceAttr("logdna_view.new", "slack_channel.#", "0"),
@@ -465,7 +524,7 @@ func TestView_MultipleChannels(t *testing.T) {
 					resource.TestCheckResourceAttr("logdna_view.new", "slack_channel.0.triggerlimit", "15"),
 					resource.TestCheckResourceAttr("logdna_view.new", "slack_channel.0.url", "https://hooks.slack.com/services/identifier/secret"),
 					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.#", "1"),
-					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.0.%", "9"),
+					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.0.%", "10"),
 					// The JSON will have newlines per our API which uses JSON.stringify(obj, null, 2) as the value
 					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.0.bodytemplate", "{\n  \"fields\": {\n    \"description\": \"{{ matches }} matches found for {{ name }}\",\n    \"issuetype\": {\n      \"name\": \"Bug\"\n    },\n    \"project\": {\n      \"key\": \"test\"\n    },\n    \"summary\": \"Alert from {{ name }}\"\n  }\n}"),
 					resource.TestCheckResourceAttr("logdna_view.new", "webhook_channel.0.headers.%", "2"),
@@ -505,6 +564,71 @@ func TestView_MultipleChannels(t *testing.T) {
 	})
 }
 
+func TestView_CategoryIds(t *testing.T) {
+	catArgs := map[string]string{
+		"name": `"DemoCategoryIds"`,
+		"type": `"views"`,
+	}
+	renamedArgs := cloneDefaults(catArgs)
+	renamedArgs["name"] = `"DemoCategoryIdsRenamed"`
+
+	rsArgs := cloneDefaults(rsDefaults["view"])
+	rsArgs["category_ids"] = `[logdna_category.cat_1.id]`
+
+	fmtCfg := func(catArgs map[string]string) string {
+		return fmt.Sprintf(
+			"%s\n%s",
+			fmtTestConfigResource("view", "new", globalPcArgs, rsArgs, nilOpt, nilLst),
+			fmtResourceBlock("category", "cat_1", catArgs, nilOpt, nilLst),
+		)
+	}
+
+	checks := resource.ComposeTestCheckFunc(
+		testResourceExists("view", "new"),
+		resource.TestCheckResourceAttr("logdna_view.new", "category_ids.#", "1"),
+		resource.TestCheckTypeSetElemAttrPair("logdna_view.new", "category_ids.*", "logdna_category.cat_1", "id"),
+		resource.TestCheckResourceAttr("logdna_view.new", "categories.#", "0"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmtCfg(catArgs),
+				Check:  checks,
+			},
+			{
+				// Renaming the category keeps the view attached to it
+				Config: fmtCfg(renamedArgs),
+				Check:  checks,
+			},
+		},
+	})
+}
+
+func TestView_ErrorsCategoryIds(t *testing.T) {
+	rsArgs := cloneDefaults(rsDefaults["view"])
+	rsArgs["category_ids"] = `["boards:abc123"]`
+
+	conflictArgs := cloneDefaults(rsDefaults["view"])
+	conflictArgs["categories"] = `["DemoCategory1"]`
+	conflictArgs["category_ids"] = `["views:abc123"]`
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmtTestConfigResource("view", "new", globalPcArgs, rsArgs, nilOpt, nilLst),
+				ExpectError: regexp.MustCompile("must reference a category of type views, got: boards"),
+			},
+			{
+				Config:      fmtTestConfigResource("view", "new", globalPcArgs, conflictArgs, nilOpt, nilLst),
+				ExpectError: regexp.MustCompile(`"category_ids": conflicts with categories`),
+			},
+		},
+	})
+}
+
 func TestView_PresetAlert(t *testing.T) {
 	chArgs := map[string]map[string]string{
 		"email_channel":     cloneDefaults(chnlDefaults["email_channel"]),
@@ -619,6 +743,87 @@ func TestView_PresetAlert(t *testing.T) {
 					resource.TestCheckResourceAttr("logdna_view.test_view", "tags.1", "tags4"),
 				),
 			},
+			{
+				ResourceName:      "logdna_view.test_view",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// NOTE presetid is deprecated and only read for configurations using it
+				ImportStateVerifyIgnore: []string{"presetid"},
+			},
+		},
+	})
+}
+
+func TestView_MultiplePresetAlerts(t *testing.T) {
+	chArgs := map[string]map[string]string{
+		"email_channel": cloneDefaults(chnlDefaults["email_channel"]),
+	}
+
+	dependencies := []string{
+		"logdna_alert.test_preset_alert_1",
+		"logdna_alert.test_preset_alert_2",
+	}
+
+	alert1Args := map[string]string{
+		"name": `"Test Alert 1"`,
+	}
+	alert2Args := map[string]string{
+		"name": `"Test Alert 2"`,
+	}
+
+	rsArgs := cloneDefaults(rsDefaults["view"])
+	rsArgs["presetids"] = `[logdna_alert.test_preset_alert_1.id, logdna_alert.test_preset_alert_2.id]`
+	iniCfg := fmt.Sprintf(
+		"%s\n%s\n%s",
+		fmtResourceBlock("alert", "test_preset_alert_1", alert1Args, chArgs, nilLst),
+		fmtResourceBlock("alert", "test_preset_alert_2", alert2Args, chArgs, nilLst),
+		fmtTestConfigResource("view", "test_view", globalPcArgs, rsArgs, nilOpt, dependencies),
+	)
+
+	rsUptd := cloneDefaults(rsDefaults["view"])
+	rsUptd["presetids"] = `[logdna_alert.test_preset_alert_2.id]`
+	updCfg := fmt.Sprintf(
+		"%s\n%s\n%s",
+		fmtResourceBlock("alert", "test_preset_alert_1", alert1Args, chArgs, nilLst),
+		fmtResourceBlock("alert", "test_preset_alert_2", alert2Args, chArgs, nilLst),
+		fmtTestConfigResource("view", "test_view", globalPcArgs, rsUptd, nilOpt, dependencies),
+	)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: iniCfg,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("view", "test_view"),
+					resource.TestCheckResourceAttr("logdna_view.test_view", "presetids.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"logdna_view.test_view",
+						"presetids.*",
+						"logdna_alert.test_preset_alert_1",
+						"id",
+					),
+					resource.TestCheckTypeSetElemAttrPair(
+						"logdna_view.test_view",
+						"presetids.*",
+						"logdna_alert.test_preset_alert_2",
+						"id",
+					),
+				),
+			},
+			{
+				Config: updCfg,
+				Check: resource.ComposeTestCheckFunc(
+					testResourceExists("view", "test_view"),
+					resource.TestCheckResourceAttr("logdna_view.test_view", "presetids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"logdna_view.test_view",
+						"presetids.*",
+						"logdna_alert.test_preset_alert_2",
+						"id",
+					),
+				),
+			},
 			{
 				ResourceName:      "logdna_view.test_view",
 				ImportState:       true,
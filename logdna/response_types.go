@@ -6,9 +6,12 @@ package logdna
 
 import (
 	"fmt"
+	"log"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type viewResponse struct {
@@ -25,6 +28,59 @@ type viewResponse struct {
 	ViewID    string            `json:"viewID"`
 }
 
+type boardResponse struct {
+	BoardID  string               `json:"boardid"`
+	Name     string               `json:"name,omitempty"`
+	Category []string             `json:"category,omitempty"`
+	Graphs   []boardGraphResponse `json:"graphs,omitempty"`
+}
+
+type boardGraphResponse struct {
+	GraphID   string              `json:"graphid"`
+	Title     string              `json:"title,omitempty"`
+	Type      string              `json:"type,omitempty"`
+	TimeRange string              `json:"timerange,omitempty"`
+	Plots     []boardPlotResponse `json:"plots,omitempty"`
+}
+
+type boardPlotResponse struct {
+	Query       string   `json:"query"`
+	Aggregation string   `json:"aggregation,omitempty"`
+	Field       string   `json:"field,omitempty"`
+	GroupBy     []string `json:"groupby,omitempty"`
+	Label       string   `json:"label,omitempty"`
+}
+
+type screenResponse struct {
+	ScreenID string                 `json:"screenid"`
+	Name     string                 `json:"name,omitempty"`
+	Category []string               `json:"category,omitempty"`
+	Widgets  []screenWidgetResponse `json:"widgets,omitempty"`
+}
+
+type screenWidgetResponse struct {
+	WidgetID    string                     `json:"widgetid"`
+	Type        string                     `json:"type"`
+	Title       string                     `json:"title,omitempty"`
+	Query       string                     `json:"query"`
+	Aggregation string                     `json:"aggregation,omitempty"`
+	Field       string                     `json:"field,omitempty"`
+	GroupBy     []string                   `json:"groupby,omitempty"`
+	TimeRange   string                     `json:"timerange,omitempty"`
+	Min         float64                    `json:"min,omitempty"`
+	Max         float64                    `json:"max,omitempty"`
+	Columns     []string                   `json:"columns,omitempty"`
+	Limit       int                        `json:"limit,omitempty"`
+	Layout      screenWidgetLayoutResponse `json:"layout"`
+}
+
+type screenWidgetLayoutResponse struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"w"`
+	Height int `json:"h"`
+}
+
 type alertResponse struct {
 	Name     string            `json:"name,omitempty"`
 	Channels []channelResponse `json:"channels,omitempty"`
@@ -40,9 +96,33 @@ type keyResponse struct {
 }
 
 type memberResponse struct {
-	Email  string   `json:"email"`
-	Role   string   `json:"role"`
-	Groups []string `json:"groups,omitempty"`
+	Email         string   `json:"email"`
+	Role          string   `json:"role"`
+	Groups        []string `json:"groups,omitempty"`
+	InviteStatus  string   `json:"invite_status,omitempty"`
+	AccountStatus string   `json:"account_status,omitempty"`
+	InvitedAt     int      `json:"invited_at,omitempty"`
+	AcceptedAt    int      `json:"accepted_at,omitempty"`
+}
+
+type groupResponse struct {
+	GroupID string              `json:"id"`
+	Name    string              `json:"name"`
+	Members []string            `json:"members,omitempty"`
+	Access  groupAccessResponse `json:"access"`
+}
+
+type groupAccessResponse struct {
+	Apps  []string `json:"apps,omitempty"`
+	Hosts []string `json:"hosts,omitempty"`
+	Views []string `json:"views,omitempty"`
+}
+
+type roleResponse struct {
+	RoleID      string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 // channelResponse contains channel data returned from the logdna APIs
@@ -85,6 +165,10 @@ type archiveResponse struct {
 	Username           string `json:"username,omitempty"`
 	Password           string `json:"password,omitempty"`
 	TenantName         string `json:"tenantname,omitempty"`
+	Region             string `json:"region,omitempty"`
+	ForcePathStyle     bool   `json:"forcepathstyle,omitempty"`
+	Status             string `json:"status,omitempty"`
+	LastError          string `json:"lasterror,omitempty"`
 }
 
 type categoryResponse struct {
@@ -131,6 +215,101 @@ func mapIndexRateAlertWebhookToSchema(indexRateAlert indexRateAlertResponse) []i
 	}
 	return webhooks
 }
+
+func (board *boardResponse) MapGraphsToSchema() []interface{} {
+	graphs := make([]interface{}, 0, len(board.Graphs))
+
+	for _, graph := range board.Graphs {
+		plots := make([]interface{}, 0, len(graph.Plots))
+		for _, plot := range graph.Plots {
+			plots = append(plots, map[string]interface{}{
+				"query":       plot.Query,
+				"aggregation": plot.Aggregation,
+				"field":       plot.Field,
+				"group_by":    plot.GroupBy,
+				"label":       plot.Label,
+			})
+		}
+
+		graphs = append(graphs, map[string]interface{}{
+			"graphid":    graph.GraphID,
+			"title":      graph.Title,
+			"type":       graph.Type,
+			"time_range": graph.TimeRange,
+			"plot":       plots,
+		})
+	}
+	return graphs
+}
+
+// MapWidgetsToSchema groups the widgets of a screen by their type, keeping
+// the order of the widgets of each type
+func (screen *screenResponse) MapWidgetsToSchema() map[string][]interface{} {
+	widgets := make(map[string][]interface{})
+	for _, widgetType := range screenWidgetTypes {
+		widgets[widgetType] = make([]interface{}, 0)
+	}
+
+	for _, widget := range screen.Widgets {
+		if _, ok := widgets[widget.Type]; !ok {
+			log.Printf("[WARN] Ignoring screen widget %s of unsupported type %s", widget.WidgetID, widget.Type)
+			continue
+		}
+
+		w := map[string]interface{}{
+			"widgetid": widget.WidgetID,
+			"title":    widget.Title,
+			"query":    widget.Query,
+			"layout": []interface{}{
+				map[string]interface{}{
+					"x":      widget.Layout.X,
+					"y":      widget.Layout.Y,
+					"width":  widget.Layout.Width,
+					"height": widget.Layout.Height,
+				},
+			},
+		}
+
+		switch widget.Type {
+		case "counter":
+			w["aggregation"] = widget.Aggregation
+			w["field"] = widget.Field
+		case "gauge":
+			w["aggregation"] = widget.Aggregation
+			w["field"] = widget.Field
+			w["min"] = widget.Min
+			w["max"] = widget.Max
+		case "table":
+			w["columns"] = widget.Columns
+			w["limit"] = widget.Limit
+		case "timeseries":
+			w["aggregation"] = widget.Aggregation
+			w["field"] = widget.Field
+			w["group_by"] = widget.GroupBy
+			w["time_range"] = widget.TimeRange
+		}
+
+		widgets[widget.Type] = append(widgets[widget.Type], w)
+	}
+	return widgets
+}
+
+// MapAccessToSchema returns the access block of a group, which is omitted
+// when the group is not restricted
+func (group *groupResponse) MapAccessToSchema() []interface{} {
+	access := group.Access
+	if len(access.Apps) == 0 && len(access.Hosts) == 0 && len(access.Views) == 0 {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"apps":  access.Apps,
+			"hosts": access.Hosts,
+			"views": access.Views,
+		},
+	}
+}
+
 func (view *viewResponse) MapChannelsToSchema() (map[string][]interface{}, diag.Diagnostics) {
 	channels := view.Channels
 	channelIntegrations, diags := mapAllChannelsToSchema("view", &channels)
@@ -196,7 +375,7 @@ func mapAllChannelsToSchema(
 func mapChannelEmail(channel *channelResponse) map[string]interface{} {
 	c := make(map[string]interface{})
 
-	c["emails"] = channel.Emails
+	c["emails"] = normalizeEmails(channel.Emails)
 	c["immediate"] = strconv.FormatBool(channel.Immediate)
 	c["operator"] = channel.Operator
 	c["terminal"] = strconv.FormatBool(channel.Terminal)
@@ -252,6 +431,61 @@ func mapChannelWebhook(channel *channelResponse) map[string]interface{} {
 	return c
 }
 
+// splitSensitiveHeaders moves the headers declared in sensitive_headers out of
+// the headers returned by the API, which always sends them as a single map.
+// Only resources know which headers are sensitive, data sources keep them all
+// in headers.
+//
+// Remote webhooks are paired with the declared ones by method and url, as the
+// API may return them in another order. A webhook without a declared
+// counterpart treats every header declared sensitive on any webhook as such,
+// so that secrets never end up in headers.
+func splitSensitiveHeaders(d *schema.ResourceData, webhooks []interface{}) {
+	declared := map[string]map[string]interface{}{}
+	anyDeclared := map[string]interface{}{}
+	for _, c := range d.Get("webhook_channel").([]interface{}) {
+		channel, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sensitive, _ := channel["sensitive_headers"].(map[string]interface{})
+		key := webhookKey(channel["method"], channel["url"])
+		if declared[key] == nil {
+			declared[key] = map[string]interface{}{}
+		}
+		for k, v := range sensitive {
+			declared[key][k] = v
+			anyDeclared[k] = v
+		}
+	}
+
+	for _, webhook := range webhooks {
+		w := webhook.(map[string]interface{})
+		headers := make(map[string]string)
+		sensitiveHeaders := make(map[string]string)
+
+		sensitive, ok := declared[webhookKey(w["method"], w["url"])]
+		if !ok {
+			sensitive = anyDeclared
+		}
+		remote, _ := w["headers"].(map[string]string)
+		for k, v := range remote {
+			if _, ok := sensitive[k]; ok {
+				sensitiveHeaders[k] = v
+			} else {
+				headers[k] = v
+			}
+		}
+
+		w["headers"] = headers
+		w["sensitive_headers"] = sensitiveHeaders
+	}
+}
+
+func webhookKey(method interface{}, url interface{}) string {
+	return strings.ToLower(fmt.Sprint(method)) + " " + fmt.Sprint(url)
+}
+
 func appendError(err error, diags *diag.Diagnostics) *diag.Diagnostics {
 	if err != nil {
 		*diags = append(*diags, diag.Diagnostic{
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,3 +52,166 @@ func TestResponseTypes_appendError(t *testing.T) {
 		assert.Equal("Some Error", result.Detail, "Detail")
 	})
 }
+
+func TestResponseTypes_splitSensitiveHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Moves declared sensitive headers out of the remote headers", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceView().Schema, map[string]interface{}{
+			"name": "test",
+			"webhook_channel": []interface{}{
+				map[string]interface{}{
+					"url":          "https://yourwebhook/endpoint",
+					"triggerlimit": 15,
+					"sensitive_headers": map[string]interface{}{
+						"Authorization": "Bearer secret",
+					},
+				},
+			},
+		})
+		webhooks := []interface{}{
+			mapChannelWebhook(&channelResponse{
+				Headers: map[string]string{
+					"Content-Type":  "application/json",
+					"Authorization": "Bearer secret",
+				},
+			}),
+		}
+
+		splitSensitiveHeaders(d, webhooks)
+
+		webhook := webhooks[0].(map[string]interface{})
+		assert.Equal(map[string]string{"Content-Type": "application/json"}, webhook["headers"])
+		assert.Equal(map[string]string{"Authorization": "Bearer secret"}, webhook["sensitive_headers"])
+	})
+
+	t.Run("Pairs webhooks by method and url", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceView().Schema, map[string]interface{}{
+			"name": "test",
+			"webhook_channel": []interface{}{
+				map[string]interface{}{
+					"url":          "https://first/endpoint",
+					"method":       "post",
+					"triggerlimit": 15,
+					"sensitive_headers": map[string]interface{}{
+						"Authorization": "Bearer first",
+					},
+				},
+				map[string]interface{}{
+					"url":          "https://second/endpoint",
+					"method":       "post",
+					"triggerlimit": 15,
+					"sensitive_headers": map[string]interface{}{
+						"X-Api-Key": "second",
+					},
+				},
+			},
+		})
+		// The API returns the webhooks in another order, one of them was edited
+		webhooks := []interface{}{
+			mapChannelWebhook(&channelResponse{
+				URL:     "https://second/endpoint",
+				Method:  "POST",
+				Headers: map[string]string{"X-Api-Key": "second", "Authorization": "Basic public"},
+			}),
+			mapChannelWebhook(&channelResponse{
+				URL:     "https://edited/endpoint",
+				Method:  "post",
+				Headers: map[string]string{"Authorization": "Bearer first", "X-Api-Key": "edited"},
+			}),
+		}
+
+		splitSensitiveHeaders(d, webhooks)
+
+		second := webhooks[0].(map[string]interface{})
+		assert.Equal(map[string]string{"Authorization": "Basic public"}, second["headers"])
+		assert.Equal(map[string]string{"X-Api-Key": "second"}, second["sensitive_headers"])
+
+		edited := webhooks[1].(map[string]interface{})
+		assert.Equal(map[string]string{}, edited["headers"], "Headers declared sensitive anywhere are kept out")
+		assert.Equal(map[string]string{"Authorization": "Bearer first", "X-Api-Key": "edited"}, edited["sensitive_headers"])
+	})
+}
+
+func TestResponseTypes_boardMapGraphsToSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	board := boardResponse{
+		BoardID: "board1",
+		Name:    "test",
+		Graphs: []boardGraphResponse{
+			{
+				GraphID:   "graph1",
+				Title:     "Errors",
+				Type:      "bar",
+				TimeRange: "24h",
+				Plots: []boardPlotResponse{
+					{Query: "level:error", Aggregation: "count", GroupBy: []string{"app"}},
+				},
+			},
+		},
+	}
+
+	assert.Equal([]interface{}{
+		map[string]interface{}{
+			"graphid":    "graph1",
+			"title":      "Errors",
+			"type":       "bar",
+			"time_range": "24h",
+			"plot": []interface{}{
+				map[string]interface{}{
+					"query":       "level:error",
+					"aggregation": "count",
+					"field":       "",
+					"group_by":    []string{"app"},
+					"label":       "",
+				},
+			},
+		},
+	}, board.MapGraphsToSchema())
+}
+
+func TestResponseTypes_screenMapWidgetsToSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	screen := screenResponse{
+		ScreenID: "screen1",
+		Widgets: []screenWidgetResponse{
+			{WidgetID: "w1", Type: "table", Query: "level:error", Columns: []string{"app"}, Limit: 10},
+			{WidgetID: "w2", Type: "heatmap"},
+			{WidgetID: "w3", Type: "table", Columns: []string{"host"}, Limit: 5, Layout: screenWidgetLayoutResponse{X: 2, Width: 2, Height: 1}},
+		},
+	}
+
+	widgets := screen.MapWidgetsToSchema()
+
+	assert.Len(widgets, 4, "Every widget type is returned")
+	assert.Empty(widgets["counter"], "No counter widgets")
+	assert.Len(widgets["table"], 2, "Unsupported widget types are ignored")
+	assert.Equal(map[string]interface{}{
+		"widgetid": "w3",
+		"title":    "",
+		"query":    "",
+		"columns":  []string{"host"},
+		"limit":    5,
+		"layout": []interface{}{
+			map[string]interface{}{"x": 2, "y": 0, "width": 2, "height": 1},
+		},
+	}, widgets["table"][1])
+}
+
+func TestResponseTypes_groupMapAccessToSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	group := groupResponse{GroupID: "group1", Name: "test"}
+	assert.Empty(group.MapAccessToSchema(), "Unrestricted groups have no access block")
+
+	group.Access.Hosts = []string{"host1"}
+	assert.Equal([]interface{}{
+		map[string]interface{}{
+			"apps":  []string(nil),
+			"hosts": []string{"host1"},
+			"views": []string(nil),
+		},
+	}, group.MapAccessToSchema())
+}
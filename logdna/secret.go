@@ -0,0 +1,99 @@
+package logdna
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Secrets are kept in the state as "salted-sha256:<salt>:<digest>" so that
+// changes to the configuration can be detected without storing the secret.
+// They are never read back from the API, which may mask or omit them.
+const secretHashPrefix = "salted-sha256:"
+
+func hashSecretWithSalt(salt []byte, value string) string {
+	digest := sha256.Sum256(append(append([]byte{}, salt...), value...))
+	return fmt.Sprintf("%s%s:%s", secretHashPrefix, hex.EncodeToString(salt), hex.EncodeToString(digest[:]))
+}
+
+func hashSecret(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("cannot generate a salt: %s", err)
+	}
+	return hashSecretWithSalt(salt, value), nil
+}
+
+func isHashedSecret(value string) bool {
+	return strings.HasPrefix(value, secretHashPrefix)
+}
+
+// secretMatches tells if a value from the configuration matches the one in
+// the state. States written by earlier versions hold the secret in clear.
+func secretMatches(stored string, value string) bool {
+	if !isHashedSecret(stored) {
+		return stored == value
+	}
+	parts := strings.Split(strings.TrimPrefix(stored, secretHashPrefix), ":")
+	if len(parts) != 2 {
+		return false
+	}
+	salt, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashSecretWithSalt(salt, value)), []byte(stored)) == 1
+}
+
+func suppressSecretDiff(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && secretMatches(old, new)
+}
+
+// storedSecret returns what should be kept in the state for a secret: its
+// hash, computed from the value being applied unless it is already hashed
+func storedSecret(d *schema.ResourceData, key string) (string, error) {
+	value, _ := d.Get(key).(string)
+	if isHashedSecret(value) {
+		return value, nil
+	}
+	return hashSecret(value)
+}
+
+// secretFromConfig returns the clear value of a secret. The state only holds
+// hashes, so it is taken from the raw configuration whenever available. The
+// secret is either a top level attribute, or part of a block with MaxItems 1.
+func secretFromConfig(d *schema.ResourceData, block string, key string) string {
+	path := key
+	if block != "" {
+		path = fmt.Sprintf("%s.0.%s", block, key)
+	}
+
+	cfg := d.GetRawConfig()
+	if cfg.IsNull() || !cfg.IsKnown() {
+		value, _ := d.Get(path).(string)
+		return value
+	}
+
+	if block != "" {
+		blocks := cfg.GetAttr(block)
+		if blocks.IsNull() || !blocks.IsKnown() || blocks.LengthInt() == 0 {
+			return ""
+		}
+		cfg = blocks.Index(cty.NumberIntVal(0))
+	}
+
+	value := cfg.GetAttr(key)
+	if value.IsNull() || !value.IsKnown() {
+		return ""
+	}
+	return value.AsString()
+}
@@ -0,0 +1,63 @@
+package logdna
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testHashSecret(t *testing.T, value string) string {
+	hashed, err := hashSecret(value)
+	assert.Nil(t, err, "No errors")
+	return hashed
+}
+
+func TestSecret_hashSecret(t *testing.T) {
+	assert := assert.New(t)
+
+	hashed := testHashSecret(t, "s3cr3t")
+	assert.True(isHashedSecret(hashed), "The secret is hashed")
+	assert.NotContains(hashed, "s3cr3t")
+	assert.NotEqual(hashed, testHashSecret(t, "s3cr3t"), "Hashes are salted")
+
+	assert.True(secretMatches(hashed, "s3cr3t"))
+	assert.False(secretMatches(hashed, "other"))
+	assert.False(secretMatches(hashed, ""))
+	assert.Equal("", testHashSecret(t, ""), "Empty secrets stay empty")
+
+	t.Run("States from earlier versions hold secrets in clear", func(t *testing.T) {
+		assert.True(secretMatches("s3cr3t", "s3cr3t"))
+		assert.False(secretMatches("s3cr3t", "other"))
+	})
+
+	t.Run("Malformed hashes never match", func(t *testing.T) {
+		assert.False(secretMatches(secretHashPrefix+"zz:00", "s3cr3t"))
+		assert.False(secretMatches(secretHashPrefix+"00", "s3cr3t"))
+	})
+}
+
+func TestSecret_suppressSecretDiff(t *testing.T) {
+	hashed := testHashSecret(t, "s3cr3t")
+
+	assert.True(t, suppressSecretDiff("password", hashed, "s3cr3t", nil), "The secret did not change")
+	assert.False(t, suppressSecretDiff("password", hashed, "rotated", nil), "The secret changed")
+	assert.False(t, suppressSecretDiff("password", "", "s3cr3t", nil), "The secret was never applied")
+}
+
+func TestSecret_storedSecret(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceStreamConfig().Schema, map[string]interface{}{
+		"password": "s3cr3t",
+	})
+
+	assert.Equal(t, "s3cr3t", secretFromConfig(d, "", "password"), "The secret is taken from the configuration")
+
+	stored, err := storedSecret(d, "password")
+	assert.Nil(t, err, "No errors")
+	assert.True(t, secretMatches(stored, "s3cr3t"), "The applied secret is hashed")
+	assert.Nil(t, d.Set("password", stored), "No errors")
+
+	kept, err := storedSecret(d, "password")
+	assert.Nil(t, err, "No errors")
+	assert.Equal(t, stored, kept, "Hashes are kept as they are")
+}